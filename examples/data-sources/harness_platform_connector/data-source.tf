@@ -0,0 +1,12 @@
+data "harness_platform_connector" "example" {
+  identifier = "identifier"
+  org_id     = "org_id"
+}
+
+data "harness_platform_connector" "by_name" {
+  name = "name"
+}
+
+output "servicenow_url" {
+  value = jsondecode(data.harness_platform_connector.example.spec).serviceNowUrl
+}
@@ -0,0 +1,8 @@
+# Import account level connector
+terraform import harness_platform_connector_yaml.example <connector_id>
+
+# Import organization level connector
+terraform import harness_platform_connector_yaml.example <org_id>/<connector_id>
+
+# Import project level connector
+terraform import harness_platform_connector_yaml.example <org_id>/<project_id>/<connector_id>
@@ -0,0 +1,38 @@
+resource "harness_platform_connector_yaml" "servicenow" {
+  identifier  = "identifier"
+  name        = "name"
+  description = "test"
+  tags        = ["foo:bar"]
+
+  yaml = <<-EOT
+    connector:
+      type: ServiceNow
+      spec:
+        serviceNowUrl: https://servicenow.com
+        delegateSelectors:
+          - harness-delegate
+        auth:
+          type: UsernamePassword
+          spec:
+            username: admin
+            passwordRef: account.secret_id
+  EOT
+}
+
+resource "harness_platform_connector_yaml" "jenkins" {
+  identifier = "identifier"
+  name       = "name"
+  org_id     = "org_id"
+
+  yaml = jsonencode({
+    connector = {
+      type = "Jenkins"
+      spec = {
+        jenkinsUrl = "https://jenkins.com"
+        auth = {
+          type = "Anonymous"
+        }
+      }
+    }
+  })
+}
@@ -1,2 +1,5 @@
 # Import using environment id
 terraform import harness_platform_environment.example <environment_id>
+
+# Import using the environment name
+terraform import harness_platform_environment.example <org_id>/<project_id>/name=<environment_name>
@@ -1,2 +1,5 @@
 # Import using organization id
 terraform import harness_organization.example <organization_id>
+
+# Import using the organization name
+terraform import harness_platform_organization.example name=<organization_name>
@@ -91,3 +91,50 @@ resource "harness_platform_pipeline" "example" {
                                   type: StageRollback
   EOT
 }
+
+# Pipelines can also be defined with stage blocks, which are rendered into the pipeline YAML.
+resource "harness_platform_pipeline" "structured" {
+  identifier = "identifier"
+  org_id     = "orgIdentifier"
+  project_id = "projectIdentifier"
+  name       = "name"
+
+  stage {
+    identifier = "greet"
+    name       = "greet"
+    type       = "Custom"
+
+    step {
+      identifier = "echo"
+      name       = "echo"
+      type       = "ShellScript"
+      timeout    = "10m"
+      spec = yamlencode({
+        shell      = "Bash"
+        onDelegate = true
+        source = {
+          type = "Inline"
+          spec = {
+            script = "echo <+pipeline.variables.greeting>"
+          }
+        }
+      })
+    }
+  }
+
+  variable {
+    name  = "greeting"
+    value = "hello"
+  }
+
+  notification_rules {
+    identifier               = "failures"
+    name                     = "failures"
+    pipeline_events          = ["PipelineFailed"]
+    notification_method_type = "Email"
+    notification_method_spec = yamlencode({
+      userGroups = []
+      recipients = ["team@example.com"]
+    })
+  }
+}
@@ -1,2 +1,5 @@
 # Import using the organization id and the project id
 terraform import harness_platform_project.example <organization_id>/<project_id>
+
+# Import using the organization id and the project name
+terraform import harness_platform_project.example <organization_id>/name=<project_name>
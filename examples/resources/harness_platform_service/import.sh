@@ -1,2 +1,5 @@
 # Import using service id
 terraform import harness_platform_service.example <service_id>
+
+# Import using the service name
+terraform import harness_platform_service.example <org_id>/<project_id>/name=<service_name>
@@ -1,2 +1,5 @@
 # Import using user group id
 terraform import harness_platform_usergroup.example <usergroup_id>
+
+# Import using the user group name
+terraform import harness_platform_usergroup.example name=<usergroup_name>
@@ -0,0 +1,86 @@
+package helpers
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/harness/terraform-provider-harness/internal"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// ApplyAdoptExisting adds the `adopt_existing` argument to the given Next Gen resources that can be
+// updated in place. When adopting is enabled for the resource or the provider, and an entity with the
+// same identifier already exists, the existing entity is taken into state and updated to match the
+// configuration instead of being created.
+func ApplyAdoptExisting(resources map[string]*schema.Resource) {
+	for name, r := range resources {
+		if r.CreateContext == nil || r.ReadContext == nil || r.UpdateContext == nil {
+			continue
+		}
+		if s, ok := r.Schema["identifier"]; !ok || !s.Required {
+			continue
+		}
+
+		r.Schema["adopt_existing"] = &schema.Schema{
+			Description: "Take an existing entity with the same identifier into state instead of failing to create it. Defaults to the provider `adopt_existing` setting.",
+			Type:        schema.TypeBool,
+			Optional:    true,
+		}
+		r.CreateContext = withAdoptExisting(r, name)
+	}
+}
+
+func withAdoptExisting(r *schema.Resource, resourceType string) func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics {
+	create, read, update := r.CreateContext, r.ReadContext, r.UpdateContext
+
+	return func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		if !adoptExisting(d, meta) {
+			return create(ctx, d, meta)
+		}
+
+		// Look the entity up before creating it, without overwriting the configuration that is about
+		// to be applied to it. Reads find the entity from its identifier and scope and set its ID,
+		// which isn't the identifier for every resource.
+		identifier := d.Get("identifier").(string)
+		d.SetId(identifier)
+		existing := r.Data(d.State())
+		d.SetId("")
+
+		if diags := read(ctx, existing, meta); diags.HasError() {
+			log.Printf("[DEBUG] Couldn't look up existing %s %s, creating it: %v", resourceType, identifier, diags)
+			return create(ctx, d, meta)
+		}
+		if existing.Id() == "" {
+			return create(ctx, d, meta)
+		}
+
+		log.Printf("[INFO] Adopting existing %s %s", resourceType, identifier)
+		d.SetId(existing.Id())
+		if diags := update(ctx, d, meta); diags.HasError() {
+			// Don't keep an entity that wasn't created by Terraform in state, it would be destroyed
+			// when the resource is replaced.
+			d.SetId("")
+			return diags
+		}
+
+		return diag.Diagnostics{{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("Adopted existing %s %s", resourceType, identifier),
+			Detail:   "An entity with this identifier already existed. It was taken into state and updated to match the configuration.",
+		}}
+	}
+}
+
+// adoptExisting returns the resource's `adopt_existing` argument, or the provider's when it isn't set.
+func adoptExisting(d *schema.ResourceData, meta interface{}) bool {
+	if config := d.GetRawConfig(); !config.IsNull() && config.Type().HasAttribute("adopt_existing") {
+		if v := config.GetAttr("adopt_existing"); !v.IsNull() && v.IsKnown() {
+			return v.True()
+		}
+	}
+
+	session, ok := meta.(*internal.Session)
+	return ok && session != nil && session.AdoptExisting
+}
@@ -0,0 +1,90 @@
+package helpers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/harness/terraform-provider-harness/internal"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyAdoptExisting(t *testing.T) {
+	var created, updated []string
+	var exists bool
+	newResource := func() *schema.Resource {
+		r := &schema.Resource{
+			Schema: map[string]*schema.Schema{},
+			CreateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+				created = append(created, d.Get("identifier").(string))
+				if exists {
+					return diag.Errorf("Project already exists")
+				}
+				d.SetId(d.Get("identifier").(string))
+				return nil
+			},
+			ReadContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+				if !exists {
+					d.SetId("")
+					return nil
+				}
+				// The ID of some entities isn't their identifier.
+				d.SetId("org/project/" + d.Get("identifier").(string))
+				d.Set("name", "existing")
+				return nil
+			},
+			UpdateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+				updated = append(updated, d.Id()+":"+d.Get("name").(string))
+				return nil
+			},
+		}
+		SetProjectLevelResourceSchema(r.Schema)
+		return r
+	}
+
+	resources := map[string]*schema.Resource{"harness_platform_project": newResource()}
+	ApplyAdoptExisting(resources)
+
+	r := resources["harness_platform_project"]
+	require.Contains(t, r.Schema, "adopt_existing")
+	config := map[string]interface{}{"identifier": "test", "name": "test", "org_id": "org", "project_id": "project"}
+	adopt := &internal.Session{AdoptExisting: true}
+
+	// Entities that don't exist yet are created.
+	d := schema.TestResourceDataRaw(t, r.Schema, config)
+	require.Nil(t, r.CreateContext(context.Background(), d, adopt))
+	require.Equal(t, "test", d.Id())
+	require.Equal(t, []string{"test"}, created)
+	require.Empty(t, updated)
+
+	// Adopting is disabled by default.
+	exists = true
+	d = schema.TestResourceDataRaw(t, r.Schema, config)
+	require.True(t, r.CreateContext(context.Background(), d, &internal.Session{}).HasError())
+	require.Empty(t, d.Id())
+	require.Len(t, created, 2)
+	require.Empty(t, updated)
+
+	// Existing entities are updated with the configuration under the ID set by the read.
+	d = schema.TestResourceDataRaw(t, r.Schema, config)
+	diags := r.CreateContext(context.Background(), d, adopt)
+	require.False(t, diags.HasError())
+	require.Equal(t, diag.Warning, diags[0].Severity)
+	require.Equal(t, "org/project/test", d.Id())
+	require.Len(t, created, 2)
+	require.Equal(t, []string{"org/project/test:test"}, updated)
+
+	// The resource argument takes precedence over the provider's.
+	attrs := map[string]cty.Value{}
+	for name, ty := range r.CoreConfigSchema().ImpliedType().AttributeTypes() {
+		attrs[name] = cty.NullVal(ty)
+	}
+	attrs["adopt_existing"] = cty.False
+	d = r.Data(&terraform.InstanceState{RawConfig: cty.ObjectVal(attrs)})
+	d.Set("identifier", "test")
+	require.True(t, r.CreateContext(context.Background(), d, adopt).HasError())
+	require.Len(t, updated, 1)
+}
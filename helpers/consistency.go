@@ -0,0 +1,74 @@
+package helpers
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// ApplyConsistencyWait makes the create and update of the given Next Gen resources wait until the
+// entity can be read back. Harness is eventually consistent, so a project that was just created
+// may still be not found by the next request, e.g. when creating the connectors in it.
+//
+// The resource's own read function is used on a copy of its state, which relies on reads clearing
+// the ID when the entity doesn't exist. The wait is bounded by the timeout of the operation.
+func ApplyConsistencyWait(resources map[string]*schema.Resource) {
+	for name, r := range resources {
+		if r.ReadContext == nil {
+			continue
+		}
+
+		r.CreateContext = withConsistencyWait(r, r.CreateContext, name, schema.TimeoutCreate)
+		r.UpdateContext = withConsistencyWait(r, r.UpdateContext, name, schema.TimeoutUpdate)
+	}
+}
+
+func withConsistencyWait(r *schema.Resource, f func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics, resourceType string, key string) func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics {
+	if f == nil {
+		return nil
+	}
+
+	read := r.ReadContext
+
+	return func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		diags := f(ctx, d, meta)
+		if diags.HasError() || d.Id() == "" {
+			return diags
+		}
+
+		stateConf := &resource.StateChangeConf{
+			Pending:    []string{"pending"},
+			Target:     []string{"visible"},
+			Timeout:    d.Timeout(key),
+			MinTimeout: 500 * time.Millisecond,
+			Refresh: func() (interface{}, string, error) {
+				visible := r.Data(d.State())
+				if readDiags := read(ctx, visible, meta); readDiags.HasError() {
+					return nil, "", fmt.Errorf("%s", readDiags[0].Summary)
+				}
+
+				if visible.Id() == "" {
+					log.Printf("[DEBUG] %s %s not visible yet", resourceType, d.Id())
+					return visible, "pending", nil
+				}
+				return visible, "visible", nil
+			},
+		}
+
+		if _, err := stateConf.WaitForStateContext(ctx); err != nil {
+			if _, ok := err.(*resource.TimeoutError); ok || ctx.Err() != nil {
+				return append(diags, diag.Errorf("%s %s was saved but could not be read back: %s", resourceType, d.Id(), err)...)
+			}
+
+			// The entity was saved, so let the next refresh report errors reading it.
+			log.Printf("[WARN] error waiting for %s %s to become visible: %s", resourceType, d.Id(), err)
+		}
+
+		return diags
+	}
+}
@@ -0,0 +1,69 @@
+package helpers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyConsistencyWait(t *testing.T) {
+	reads := 0
+	newResource := func() *schema.Resource {
+		r := &schema.Resource{
+			Schema: map[string]*schema.Schema{},
+			CreateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+				d.SetId("test")
+				d.Set("name", "test")
+				return nil
+			},
+			ReadContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+				reads++
+				require.Equal(t, "test", d.Get("name"))
+
+				// The entity is only found from the third read.
+				if reads < 3 {
+					d.SetId("")
+				} else {
+					d.Set("description", "changed by read")
+				}
+				return nil
+			},
+		}
+		SetProjectLevelResourceSchema(r.Schema)
+		return r
+	}
+
+	resources := map[string]*schema.Resource{"harness_platform_project": newResource()}
+	ApplyConsistencyWait(resources)
+
+	r := resources["harness_platform_project"]
+	d := r.TestResourceData()
+	require.Nil(t, r.CreateContext(context.Background(), d, nil))
+	require.Equal(t, 3, reads)
+	require.Equal(t, "test", d.Id())
+	require.Empty(t, d.Get("description"))
+}
+
+func TestApplyConsistencyWaitTimeout(t *testing.T) {
+	r := &schema.Resource{
+		Schema: map[string]*schema.Schema{},
+		CreateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			d.SetId("test")
+			return nil
+		},
+		ReadContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			d.SetId("")
+			return nil
+		},
+	}
+	ApplyConsistencyWait(map[string]*schema.Resource{"harness_platform_project": r})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	diags := r.CreateContext(ctx, r.TestResourceData(), nil)
+	require.True(t, diags.HasError())
+	require.Contains(t, diags[0].Summary, "harness_platform_project test was saved but could not be read back")
+}
@@ -1,22 +1,223 @@
 package helpers
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
 	"net/http"
+	"regexp"
+	"strings"
 
-	"github.com/harness/harness-go-sdk/harness/nextgen"
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
+const unauthorizedHint = "Hint:\n" +
+	"1) Please check if token has expired or is wrong.\n" +
+	"2) Harness Provider is misconfigured. For firstgen resources please give the correct api_key and for nextgen resources please give the correct platform_api_key."
+
+// apiErrorBody holds the fields shared by the error bodies of the NG services. The error models
+// differ between services, so the body is decoded directly instead of using the swagger model.
+type apiErrorBody struct {
+	Code          interface{} `json:"code"`
+	Message       string      `json:"message"`
+	CorrelationId string      `json:"correlationId"`
+	Errors        []struct {
+		FieldId string `json:"fieldId"`
+		Error   string `json:"error"`
+	} `json:"errors"`
+	ResponseMessages []struct {
+		Code    string `json:"code"`
+		Level   string `json:"level"`
+		Message string `json:"message"`
+	} `json:"responseMessages"`
+}
+
+// apiError is implemented by the errors of the Next Gen SDK and internal.ApiError, which expose the
+// body of the error response.
+type apiError interface {
+	error
+	Body() []byte
+}
+
+func parseApiErrorBody(err error) (*apiErrorBody, bool) {
+	erro, ok := err.(apiError)
+	if !ok {
+		return nil, false
+	}
+
+	body := &apiErrorBody{}
+	if json.Unmarshal(erro.Body(), body) != nil {
+		return nil, false
+	}
+
+	return body, true
+}
+
+// code returns the Harness error code. Some services return numeric codes, which are ignored.
+func (b *apiErrorBody) code() string {
+	code, _ := b.Code.(string)
+	return code
+}
+
 func HandleApiError(err error, d *schema.ResourceData, httpResp *http.Response) diag.Diagnostics {
-	erro, ok := err.(nextgen.GenericSwaggerError)
-	if ok {
-		if httpResp != nil && httpResp.StatusCode == 401 {
-			return diag.Errorf(httpResp.Status + "\n" + "Hint:\n" +
-				"1) Please check if token has expired or is wrong.\n" +
-				"2) Harness Provider is misconfigured. For firstgen resources please give the correct api_key and for nextgen resources please give the correct platform_api_key.")
+	erro, ok := err.(apiError)
+	if !ok {
+		return diag.Errorf(err.Error())
+	}
+
+	summary := erro.Error()
+	var details []string
+
+	if httpResp != nil && httpResp.StatusCode == http.StatusUnauthorized {
+		summary = httpResp.Status
+		details = append(details, unauthorizedHint)
+	}
+
+	body, ok := parseApiErrorBody(err)
+	if !ok {
+		return diag.Diagnostics{{Severity: diag.Error, Summary: summary, Detail: strings.Join(details, "\n")}}
+	}
+
+	for _, m := range body.ResponseMessages {
+		// The first response message is usually the same as the error message.
+		if m.Message != "" && m.Message != summary {
+			details = append(details, fmt.Sprintf("%s %s: %s", m.Level, m.Code, m.Message))
+		}
+	}
+	if code := body.code(); code != "" {
+		details = append(details, fmt.Sprintf("Error code: %s", code))
+	}
+	if body.CorrelationId != "" {
+		details = append(details, fmt.Sprintf("Correlation ID: %s", body.CorrelationId))
+	}
+	detail := strings.Join(details, "\n")
+
+	// Point each field error at the attribute it refers to.
+	var diags diag.Diagnostics
+	for _, e := range body.Errors {
+		fieldDiag := diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("Invalid %s: %s", e.FieldId, e.Error),
+			Detail:   detail,
 		}
-		return diag.Errorf(erro.Error())
+		if attr := fieldAttribute(d, e.FieldId); attr != "" {
+			fieldDiag.AttributePath = cty.GetAttrPath(attr)
+		}
+		diags = append(diags, fieldDiag)
+	}
+
+	if len(diags) == 0 {
+		diags = append(diags, diag.Diagnostic{Severity: diag.Error, Summary: summary, Detail: detail})
 	}
-	return diag.Errorf(err.Error())
+
+	return diags
+}
+
+// fieldAttributes maps the field names used by the Harness API to the attribute names used in
+// the schema when they aren't just the snake case form of the field.
+var fieldAttributes = map[string]string{
+	"orgIdentifier":      "org_id",
+	"projectIdentifier":  "project_id",
+	"pipelineIdentifier": "pipeline_id",
+	"targetIdentifier":   "target_id",
+	"envIdentifier":      "env_id",
+	"serviceIdentifier":  "service_id",
+}
+
+var camelCaseBoundary = regexp.MustCompile(`([a-z0-9])([A-Z])`)
+
+// fieldAttribute returns the top level attribute of the resource named by an API field id such
+// as `connector.spec.url`, or an empty string when the resource has no such attribute.
+func fieldAttribute(d *schema.ResourceData, fieldId string) string {
+	if d == nil || fieldId == "" {
+		return ""
+	}
+
+	field := fieldId[strings.LastIndex(fieldId, ".")+1:]
+	attr, ok := fieldAttributes[field]
+	if !ok {
+		attr = strings.ToLower(camelCaseBoundary.ReplaceAllString(field, "${1}_${2}"))
+	}
+
+	if d.Get(attr) == nil {
+		return ""
+	}
+	return attr
+}
+
+// notFoundCodes are the Harness error codes returned when the requested entity doesn't exist.
+var notFoundCodes = map[string]bool{
+	"ENTITY_NOT_FOUND":   true,
+	"RESOURCE_NOT_FOUND": true,
+}
+
+// IsNotFound reports whether an API error means that the requested entity doesn't exist, either
+// through a 404 response or one of the Harness not found error codes.
+func IsNotFound(err error, httpResp *http.Response) bool {
+	if httpResp != nil && httpResp.StatusCode == http.StatusNotFound {
+		return true
+	}
+
+	body, ok := parseApiErrorBody(err)
+	return ok && notFoundCodes[body.code()]
+}
+
+// HandleReadApiError handles an error returned when reading a resource. When the entity no longer
+// exists its ID is cleared so Terraform removes it from state and plans to create it again. Data
+// sources, which are read without an ID, still fail.
+func HandleReadApiError(err error, d *schema.ResourceData, httpResp *http.Response) diag.Diagnostics {
+	if d.Id() != "" && IsNotFound(err, httpResp) {
+		log.Printf("[WARN] %s not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	return HandleApiError(err, d, httpResp)
+}
+
+// cdErrorPattern matches the errors returned by the CD client for GraphQL response messages,
+// `LEVEL CODE: message`, and config as code response messages, `CODE: message`.
+var cdErrorPattern = regexp.MustCompile(`^(?:(?:ERROR|WARN|INFO) )?([A-Z][A-Z0-9_]+): (?s)(.+)$`)
+
+// ApplyCDErrorDiagnostics splits the error code out of the errors returned by the given First Gen
+// resources so diagnostics show the message as the summary and the code as the detail.
+func ApplyCDErrorDiagnostics(resources map[string]*schema.Resource) {
+	for _, r := range resources {
+		r.CreateContext = withCDErrorDiagnostics(r.CreateContext)
+		r.ReadContext = withCDErrorDiagnostics(r.ReadContext)
+		r.UpdateContext = withCDErrorDiagnostics(r.UpdateContext)
+		r.DeleteContext = withCDErrorDiagnostics(r.DeleteContext)
+	}
+}
+
+func withCDErrorDiagnostics(f func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics) func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics {
+	if f == nil {
+		return nil
+	}
+
+	return func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		diags := f(ctx, d, meta)
+		for i := range diags {
+			diags[i] = cdErrorDiagnostic(diags[i])
+		}
+		return diags
+	}
+}
+
+func cdErrorDiagnostic(d diag.Diagnostic) diag.Diagnostic {
+	if d.Severity != diag.Error || d.Detail != "" {
+		return d
+	}
+
+	m := cdErrorPattern.FindStringSubmatch(d.Summary)
+	if m == nil {
+		return d
+	}
+
+	d.Summary = m[2]
+	d.Detail = fmt.Sprintf("Error code: %s", m[1])
+	return d
 }
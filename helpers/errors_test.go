@@ -0,0 +1,148 @@
+package helpers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/harness/harness-go-sdk/harness/nextgen"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/go-retryablehttp"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/require"
+)
+
+// getProjectError returns the error of a GetProject call answered with the given status and body.
+func getProjectError(t *testing.T, status int, body string) (error, *http.Response) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		w.Write([]byte(body))
+	}))
+	defer server.Close()
+
+	httpClient := retryablehttp.NewClient()
+	httpClient.RetryMax = 0
+	c := nextgen.NewAPIClient(&nextgen.Configuration{
+		AccountId:  "test",
+		BasePath:   server.URL,
+		HTTPClient: httpClient,
+	})
+
+	_, httpResp, err := c.ProjectApi.GetProject(context.Background(), "test", "test", nil)
+	require.Error(t, err)
+	return err, httpResp
+}
+
+func TestIsNotFound(t *testing.T) {
+	err, httpResp := getProjectError(t, http.StatusNotFound, `{"status":"ERROR","message":"not found"}`)
+	require.True(t, IsNotFound(err, httpResp))
+
+	err, httpResp = getProjectError(t, http.StatusBadRequest, `{"status":"ERROR","code":"ENTITY_NOT_FOUND","message":"Project not found"}`)
+	require.True(t, IsNotFound(err, httpResp))
+
+	err, httpResp = getProjectError(t, http.StatusBadRequest, `{"status":"ERROR","code":"RESOURCE_NOT_FOUND","message":"Resource not found"}`)
+	require.True(t, IsNotFound(err, httpResp))
+
+	err, httpResp = getProjectError(t, http.StatusBadRequest, `{"status":"ERROR","code":"INVALID_REQUEST","message":"Invalid request"}`)
+	require.False(t, IsNotFound(err, httpResp))
+}
+
+func TestHandleReadApiError(t *testing.T) {
+	r := &schema.Resource{Schema: map[string]*schema.Schema{}}
+	SetProjectLevelResourceSchema(r.Schema)
+
+	err, httpResp := getProjectError(t, http.StatusBadRequest, `{"status":"ERROR","code":"ENTITY_NOT_FOUND","message":"Project not found"}`)
+
+	d := r.TestResourceData()
+	d.SetId("test")
+	require.Nil(t, HandleReadApiError(err, d, httpResp))
+	require.Empty(t, d.Id())
+
+	// Data sources are read without an ID and must still fail.
+	diags := HandleReadApiError(err, r.TestResourceData(), httpResp)
+	require.True(t, diags.HasError())
+	require.Equal(t, "Project not found", diags[0].Summary)
+
+	err, httpResp = getProjectError(t, http.StatusBadRequest, `{"status":"ERROR","code":"INVALID_REQUEST","message":"Invalid request"}`)
+	d.SetId("test")
+	require.True(t, HandleReadApiError(err, d, httpResp).HasError())
+	require.Equal(t, "test", d.Id())
+}
+
+func TestHandleApiError(t *testing.T) {
+	r := &schema.Resource{Schema: map[string]*schema.Schema{}}
+	SetProjectLevelResourceSchema(r.Schema)
+	d := r.TestResourceData()
+
+	err, httpResp := getProjectError(t, http.StatusBadRequest, `{
+		"status": "ERROR",
+		"code": "INVALID_REQUEST",
+		"message": "Invalid request: Project does not exist",
+		"correlationId": "abc-123",
+		"responseMessages": [
+			{"code": "INVALID_REQUEST", "level": "ERROR", "message": "Invalid request: Project does not exist"},
+			{"code": "ENTITY_NOT_FOUND", "level": "ERROR", "message": "Project does not exist"}
+		]
+	}`)
+	diags := HandleApiError(err, d, httpResp)
+	require.Len(t, diags, 1)
+	require.Equal(t, "Invalid request: Project does not exist", diags[0].Summary)
+	require.Equal(t, "ERROR ENTITY_NOT_FOUND: Project does not exist\nError code: INVALID_REQUEST\nCorrelation ID: abc-123", diags[0].Detail)
+
+	err, httpResp = getProjectError(t, http.StatusBadRequest, `{
+		"status": "ERROR",
+		"code": "INVALID_ARGUMENT",
+		"message": "Invalid request",
+		"correlationId": "abc-123",
+		"errors": [
+			{"fieldId": "project.orgIdentifier", "error": "must not be blank"},
+			{"fieldId": "project.description", "error": "size must be between 0 and 1024"},
+			{"fieldId": "project.modules", "error": "must not be null"}
+		]
+	}`)
+	diags = HandleApiError(err, d, httpResp)
+	require.Len(t, diags, 3)
+	require.Equal(t, "Invalid project.orgIdentifier: must not be blank", diags[0].Summary)
+	require.Equal(t, cty.GetAttrPath("org_id"), diags[0].AttributePath)
+	require.Equal(t, "Error code: INVALID_ARGUMENT\nCorrelation ID: abc-123", diags[0].Detail)
+	require.Equal(t, cty.GetAttrPath("description"), diags[1].AttributePath)
+	require.Nil(t, diags[2].AttributePath)
+
+	err, httpResp = getProjectError(t, http.StatusUnauthorized, `{"status":"ERROR","code":"INVALID_TOKEN","message":"Token is not valid."}`)
+	diags = HandleApiError(err, d, httpResp)
+	require.Len(t, diags, 1)
+	require.Equal(t, httpResp.Status, diags[0].Summary)
+	require.Contains(t, diags[0].Detail, "Hint:")
+	require.Contains(t, diags[0].Detail, "Error code: INVALID_TOKEN")
+}
+
+func TestApplyCDErrorDiagnostics(t *testing.T) {
+	errs := []error{
+		errors.New("ERROR INVALID_ARGUMENT: Application name must be unique"),
+		errors.New("INVALID_REQUEST: Invalid yaml"),
+		errors.New("unauthorized"),
+	}
+
+	r := &schema.Resource{
+		ReadContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			var diags diag.Diagnostics
+			for _, err := range errs {
+				diags = append(diags, diag.FromErr(err)...)
+			}
+			return diags
+		},
+	}
+	ApplyCDErrorDiagnostics(map[string]*schema.Resource{"harness_application": r})
+
+	diags := r.ReadContext(context.Background(), nil, nil)
+	require.Equal(t, "Application name must be unique", diags[0].Summary)
+	require.Equal(t, "Error code: INVALID_ARGUMENT", diags[0].Detail)
+	require.Equal(t, "Invalid yaml", diags[1].Summary)
+	require.Equal(t, "Error code: INVALID_REQUEST", diags[1].Detail)
+	require.Equal(t, "unauthorized", diags[2].Summary)
+	require.Empty(t, diags[2].Detail)
+}
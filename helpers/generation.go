@@ -0,0 +1,108 @@
+package helpers
+
+import (
+	"context"
+	"fmt"
+
+	hh "github.com/harness/harness-go-sdk/harness/helpers"
+	"github.com/harness/terraform-provider-harness/internal"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Generation is the Harness product generation a resource belongs to, which determines the api key
+// it needs.
+type Generation string
+
+const (
+	FirstGen Generation = "First Gen"
+	NextGen  Generation = "Next Gen"
+)
+
+// ApplyGeneration makes the given resources of a generation fail with an error naming the missing
+// provider argument when the api key for that generation isn't configured. Resources are checked
+// during plan and data sources when they are read.
+func ApplyGeneration(resources map[string]*schema.Resource, generation Generation, dataSource bool) {
+	for name, r := range resources {
+		resourceType := name
+		kind := "resource"
+		if dataSource {
+			kind = "data source"
+		}
+
+		check := func(meta interface{}) error {
+			return checkGeneration(resourceType, generation, kind, meta)
+		}
+
+		if !dataSource {
+			customizeDiff := func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+				return check(meta)
+			}
+			if r.CustomizeDiff != nil {
+				r.CustomizeDiff = customdiff.Sequence(customizeDiff, r.CustomizeDiff)
+			} else {
+				r.CustomizeDiff = customizeDiff
+			}
+		}
+
+		r.CreateContext = withGeneration(r.CreateContext, check)
+		r.ReadContext = withGeneration(r.ReadContext, check)
+		r.UpdateContext = withGeneration(r.UpdateContext, check)
+		r.DeleteContext = withGeneration(r.DeleteContext, check)
+
+		if r.Importer != nil && r.Importer.StateContext != nil {
+			// Importers are shared between resources so wrap a copy.
+			importer := *r.Importer
+			stateContext := importer.StateContext
+			importer.StateContext = func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+				if err := check(meta); err != nil {
+					return nil, err
+				}
+				return stateContext(ctx, d, meta)
+			}
+			r.Importer = &importer
+		}
+	}
+}
+
+func withGeneration(f func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics, check func(interface{}) error) func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics {
+	if f == nil {
+		return nil
+	}
+
+	return func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		if err := check(meta); err != nil {
+			return diag.FromErr(err)
+		}
+		return f(ctx, d, meta)
+	}
+}
+
+func checkGeneration(resourceType string, generation Generation, kind string, meta interface{}) error {
+	session, ok := meta.(*internal.Session)
+	if !ok || session == nil {
+		return nil
+	}
+
+	var arg, envVar string
+
+	switch generation {
+	case NextGen:
+		if session.HasPLClient() {
+			return nil
+		}
+		arg, envVar = "platform_api_key", hh.EnvVars.PlatformApiKey.String()
+	default:
+		if session.HasCDClient() {
+			if _, err := session.GetCDClientWithError(); err != nil {
+				return fmt.Errorf("error creating CD client: %s", err)
+			}
+			return nil
+		}
+		arg, envVar = "api_key", hh.EnvVars.ApiKey.String()
+	}
+
+	return fmt.Errorf("%s is a %s %s and requires the `%s` provider argument, which is not set. Set it in the provider configuration, the `%s` environment variable or the credentials profile.",
+		resourceType, generation, kind, arg, envVar)
+}
@@ -0,0 +1,54 @@
+package helpers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/harness/harness-go-sdk/harness/cd"
+	"github.com/harness/harness-go-sdk/harness/nextgen"
+	"github.com/harness/terraform-provider-harness/internal"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyGeneration(t *testing.T) {
+	read := func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		return nil
+	}
+	newResource := func() *schema.Resource {
+		r := &schema.Resource{Schema: map[string]*schema.Schema{}, ReadContext: read}
+		SetProjectLevelResourceSchema(r.Schema)
+		return r
+	}
+
+	resources := map[string]*schema.Resource{
+		"harness_platform_project": newResource(),
+		"harness_application":      newResource(),
+	}
+	ApplyGeneration(map[string]*schema.Resource{"harness_platform_project": resources["harness_platform_project"]}, NextGen, false)
+	ApplyGeneration(map[string]*schema.Resource{"harness_application": resources["harness_application"]}, FirstGen, false)
+	dataSources := map[string]*schema.Resource{"harness_platform_project": newResource()}
+	ApplyGeneration(dataSources, NextGen, true)
+	require.Nil(t, dataSources["harness_platform_project"].CustomizeDiff)
+
+	cdOnly := &internal.Session{NewCDClient: func() (*cd.ApiClient, error) { return &cd.ApiClient{}, nil }}
+	plOnly := &internal.Session{NewPLClient: func() *nextgen.APIClient { return &nextgen.APIClient{} }}
+	config := map[string]interface{}{"identifier": "test", "name": "test", "org_id": "org", "project_id": "project"}
+
+	_, err := testDiff(t, resources["harness_platform_project"], config, cdOnly)
+	require.EqualError(t, err, "harness_platform_project is a Next Gen resource and requires the `platform_api_key` provider argument, which is not set. "+
+		"Set it in the provider configuration, the `HARNESS_PLATFORM_API_KEY` environment variable or the credentials profile.")
+	_, err = testDiff(t, resources["harness_platform_project"], config, plOnly)
+	require.NoError(t, err)
+
+	_, err = testDiff(t, resources["harness_application"], config, plOnly)
+	require.ErrorContains(t, err, "harness_application is a First Gen resource and requires the `api_key` provider argument")
+	_, err = testDiff(t, resources["harness_application"], config, cdOnly)
+	require.NoError(t, err)
+
+	diags := dataSources["harness_platform_project"].ReadContext(context.Background(), nil, cdOnly)
+	require.True(t, diags.HasError())
+	require.Contains(t, diags[0].Summary, "harness_platform_project is a Next Gen data source")
+	require.Nil(t, dataSources["harness_platform_project"].ReadContext(context.Background(), nil, plOnly))
+}
@@ -0,0 +1,197 @@
+package helpers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// ImportIdFormat is a format of import IDs, given as the attribute set from each segment of the ID,
+// e.g. `{"org_id", "project_id", "identifier"}`.
+type ImportIdFormat []string
+
+// LookupByNameFunc returns the identifier of the entity with the given name. The attributes parsed
+// from the other segments of the import ID are already set on d.
+type LookupByNameFunc func(ctx context.Context, d *schema.ResourceData, meta interface{}, name string) (string, error)
+
+// importNamePrefix marks the segment of the ID attribute as a name to look up instead of an identifier.
+const importNamePrefix = "name="
+
+// scopeDefaultArguments are the provider arguments used for scope attributes missing from an import ID.
+var scopeDefaultArguments = map[string]string{
+	"org_id":     "default_org_id",
+	"project_id": "default_project_id",
+}
+
+// ImportIdParser parses the import IDs of a resource. The format is picked by the number of segments
+// in the ID, so every format must have a different number of segments.
+type ImportIdParser struct {
+	// Formats are the accepted formats, the most specific first.
+	Formats []ImportIdFormat
+	// Required are the attributes that must be set by the ID or, for scope attributes, the provider
+	// defaults. Scope attributes that aren't required are never filled from the defaults.
+	Required []string
+	// IdAttribute is the attribute used as the resource ID, `identifier` when empty.
+	IdAttribute string
+	// KeepId keeps the import ID as the resource ID, for resources whose read sets the ID from the
+	// parsed attributes.
+	KeepId bool
+	// Separator separates the segments of the ID, `/` when empty.
+	Separator string
+	// LookupByName allows the segment of the ID attribute to be given as `name=<name>`.
+	LookupByName LookupByNameFunc
+}
+
+// WithLookupByName returns a copy of the parser that accepts `name=<name>` for the ID attribute.
+func (p ImportIdParser) WithLookupByName(f LookupByNameFunc) *ImportIdParser {
+	p.LookupByName = f
+	return &p
+}
+
+// Importer returns a resource importer that parses the import ID with p.
+func (p *ImportIdParser) Importer() *schema.ResourceImporter {
+	return &schema.ResourceImporter{StateContext: p.importState}
+}
+
+func (p *ImportIdParser) separator() string {
+	if p.Separator == "" {
+		return "/"
+	}
+	return p.Separator
+}
+
+func (p *ImportIdParser) idAttribute() string {
+	if p.IdAttribute == "" {
+		return "identifier"
+	}
+	return p.IdAttribute
+}
+
+// Parse returns the attributes set by an import ID, with required scope attributes missing from the
+// ID filled from the provider defaults.
+func (p *ImportIdParser) Parse(id string, meta interface{}) (map[string]string, error) {
+	segments := strings.Split(id, p.separator())
+
+	var format ImportIdFormat
+	for _, f := range p.Formats {
+		if len(f) == len(segments) {
+			format = f
+			break
+		}
+	}
+	if format == nil {
+		return nil, p.error(id, "")
+	}
+
+	attributes := map[string]string{}
+	for i, attr := range format {
+		if segments[i] == "" {
+			return nil, p.error(id, fmt.Sprintf("`%s` is empty", attr))
+		}
+		attributes[attr] = segments[i]
+	}
+
+	// Only required scope attributes are filled from the defaults. For the others, the number of
+	// segments of the ID decides the scope, e.g. `<identifier>` imports an account level entity.
+	orgId, projectId := GetScopeDefaults(meta)
+	defaults := map[string]string{"org_id": orgId, "project_id": projectId}
+	for _, attr := range p.Required {
+		if _, ok := attributes[attr]; !ok && defaults[attr] != "" {
+			attributes[attr] = defaults[attr]
+		}
+	}
+
+	for _, attr := range p.Required {
+		if attributes[attr] == "" {
+			return nil, p.error(id, fmt.Sprintf("`%s` isn't part of the ID and the provider `%s` isn't set", attr, scopeDefaultArguments[attr]))
+		}
+	}
+
+	return attributes, nil
+}
+
+func (p *ImportIdParser) importState(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	attributes, err := p.Parse(d.Id(), meta)
+	if err != nil {
+		return nil, err
+	}
+
+	idAttr := p.idAttribute()
+	name, byName := "", false
+	if p.LookupByName != nil {
+		name, byName = cutPrefix(attributes[idAttr], importNamePrefix)
+	}
+
+	for attr, value := range attributes {
+		if attr != idAttr {
+			d.Set(attr, value)
+		}
+	}
+
+	if p.KeepId {
+		return []*schema.ResourceData{d}, nil
+	}
+
+	id := attributes[idAttr]
+	if byName {
+		if id, err = p.LookupByName(ctx, d, meta, name); err != nil {
+			return nil, err
+		}
+	}
+
+	d.Set(idAttr, id)
+	d.SetId(id)
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// error returns the error for an invalid import ID, listing the accepted formats.
+func (p *ImportIdParser) error(id string, reason string) error {
+	var formats []string
+	for _, f := range p.Formats {
+		var segments, defaults []string
+		for _, attr := range f {
+			segments = append(segments, fmt.Sprintf("<%s>", attr))
+		}
+		for _, attr := range p.Required {
+			if !f.contains(attr) && scopeDefaultArguments[attr] != "" {
+				defaults = append(defaults, fmt.Sprintf("`%s`", scopeDefaultArguments[attr]))
+			}
+		}
+
+		format := "  " + strings.Join(segments, p.separator())
+		if len(defaults) > 0 {
+			format += fmt.Sprintf(" (with the provider %s set)", strings.Join(defaults, " and "))
+		}
+		formats = append(formats, format)
+	}
+
+	msg := fmt.Sprintf("invalid import ID %q", id)
+	if reason != "" {
+		msg += ": " + reason
+	}
+	msg += ". Expected one of:\n" + strings.Join(formats, "\n")
+	if p.LookupByName != nil {
+		msg += fmt.Sprintf("\n<%s> can also be given as %s<name>.", p.idAttribute(), importNamePrefix)
+	}
+
+	return fmt.Errorf("%s", msg)
+}
+
+func (f ImportIdFormat) contains(attr string) bool {
+	for _, a := range f {
+		if a == attr {
+			return true
+		}
+	}
+	return false
+}
+
+func cutPrefix(s string, prefix string) (string, bool) {
+	if !strings.HasPrefix(s, prefix) {
+		return s, false
+	}
+	return strings.TrimPrefix(s, prefix), true
+}
@@ -0,0 +1,111 @@
+package helpers
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/harness/terraform-provider-harness/internal"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImportIdParser(t *testing.T) {
+	parser := &ImportIdParser{Formats: []ImportIdFormat{{"org_id", "project_id", "pipeline_id", "identifier"}}}
+	attributes, err := parser.Parse("org/project/pipeline/test", nil)
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"org_id": "org", "project_id": "project", "pipeline_id": "pipeline", "identifier": "test"}, attributes)
+
+	// Malformed IDs fail with the expected formats instead of panicking.
+	for _, id := range []string{"", "test", "org/project/test", "org/project/pipeline/test/extra"} {
+		d := schema.TestResourceDataRaw(t, map[string]*schema.Schema{}, map[string]interface{}{})
+		d.SetId(id)
+		_, err := PipelineResourceImporter.StateContext(context.Background(), d, nil)
+		require.EqualError(t, err, "invalid import ID \""+id+"\". Expected one of:\n  <org_id>/<project_id>/<pipeline_id>/<identifier>")
+	}
+
+	_, err = ProjectResourceImportIdParser.Parse("org//test", nil)
+	require.EqualError(t, err, "invalid import ID \"org//test\": `project_id` is empty. Expected one of:\n"+
+		"  <org_id>/<project_id>/<identifier>\n"+
+		"  <project_id>/<identifier> (with the provider `default_org_id` set)\n"+
+		"  <identifier> (with the provider `default_org_id` and `default_project_id` set)")
+
+	_, err = ProjectResourceImportIdParser.Parse("project/test", &internal.Session{})
+	require.ErrorContains(t, err, "`org_id` isn't part of the ID and the provider `default_org_id` isn't set")
+
+	// The scope of multi level resources is decided by the ID alone.
+	defaults := &internal.Session{DefaultOrgId: "default_org", DefaultProjectId: "default_project"}
+	attributes, err = MultiLevelResourceImportIdParser.Parse("org/test", defaults)
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"org_id": "org", "identifier": "test"}, attributes)
+
+	attributes, err = MultiLevelResourceImportIdParser.Parse("test", defaults)
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"identifier": "test"}, attributes)
+
+	attributes, err = MultiLevelResourceImportIdParser.Parse("test", &internal.Session{})
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"identifier": "test"}, attributes)
+}
+
+func TestImportIdParserKeepId(t *testing.T) {
+	r := &schema.Resource{Schema: map[string]*schema.Schema{
+		"path":   {Type: schema.TypeString, Required: true},
+		"app_id": {Type: schema.TypeString, Optional: true},
+	}}
+	importer := (&ImportIdParser{
+		Formats:   []ImportIdFormat{{"path", "app_id"}, {"path"}},
+		KeepId:    true,
+		Separator: ":",
+	}).Importer()
+
+	d := r.TestResourceData()
+	d.SetId("Setup/Applications/app/Index.yaml:app")
+	result, err := importer.StateContext(context.Background(), d, nil)
+	require.NoError(t, err)
+	require.Equal(t, "Setup/Applications/app/Index.yaml:app", result[0].Id())
+	require.Equal(t, "Setup/Applications/app/Index.yaml", result[0].Get("path"))
+	require.Equal(t, "app", result[0].Get("app_id"))
+
+	d = r.TestResourceData()
+	d.SetId("a:b:c")
+	_, err = importer.StateContext(context.Background(), d, nil)
+	require.EqualError(t, err, "invalid import ID \"a:b:c\". Expected one of:\n  <path>:<app_id>\n  <path>")
+}
+
+func TestImportIdParserLookupByName(t *testing.T) {
+	r := testScopeResource(SetProjectLevelResourceSchema)
+	importer := ProjectResourceImportIdParser.WithLookupByName(func(ctx context.Context, d *schema.ResourceData, meta interface{}, name string) (string, error) {
+		if name != "My Service" {
+			return "", errors.New("not found")
+		}
+		require.Equal(t, "org", d.Get("org_id"))
+		require.Equal(t, "project", d.Get("project_id"))
+		return "my_service", nil
+	}).Importer()
+	require.Nil(t, ProjectResourceImportIdParser.LookupByName)
+
+	d := r.TestResourceData()
+	d.SetId("org/project/name=My Service")
+	result, err := importer.StateContext(context.Background(), d, nil)
+	require.NoError(t, err)
+	require.Equal(t, "my_service", result[0].Id())
+	require.Equal(t, "my_service", result[0].Get("identifier"))
+
+	d = r.TestResourceData()
+	d.SetId("org/project/name=Other")
+	_, err = importer.StateContext(context.Background(), d, nil)
+	require.EqualError(t, err, "not found")
+
+	// Without a lookup the segment is used as the identifier.
+	d = r.TestResourceData()
+	d.SetId("org/project/name=My Service")
+	result, err = ProjectResourceImporter.StateContext(context.Background(), d, nil)
+	require.NoError(t, err)
+	require.Equal(t, "name=My Service", result[0].Id())
+
+	d = r.TestResourceData()
+	d.SetId("a/b/c/d")
+	_, err = importer.StateContext(context.Background(), d, nil)
+	require.ErrorContains(t, err, "\n<identifier> can also be given as name=<name>.")
+}
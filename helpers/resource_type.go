@@ -0,0 +1,43 @@
+package helpers
+
+import (
+	"context"
+
+	"github.com/harness/terraform-provider-harness/internal"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// ApplyResourceType records the resource type in the context passed to the CRUD and import
+// functions of the given resources so API calls can be attributed to it. The type is prefixed
+// with prefix, e.g. `data.` for data sources.
+func ApplyResourceType(resources map[string]*schema.Resource, prefix string) {
+	for name, r := range resources {
+		resourceType := prefix + name
+
+		r.CreateContext = withResourceType(r.CreateContext, resourceType)
+		r.ReadContext = withResourceType(r.ReadContext, resourceType)
+		r.UpdateContext = withResourceType(r.UpdateContext, resourceType)
+		r.DeleteContext = withResourceType(r.DeleteContext, resourceType)
+
+		if r.Importer != nil && r.Importer.StateContext != nil {
+			// Importers are shared between resources so wrap a copy.
+			importer := *r.Importer
+			stateContext := importer.StateContext
+			importer.StateContext = func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+				return stateContext(internal.WithResourceType(ctx, resourceType), d, meta)
+			}
+			r.Importer = &importer
+		}
+	}
+}
+
+func withResourceType(f func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics, resourceType string) func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics {
+	if f == nil {
+		return nil
+	}
+
+	return func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		return f(internal.WithResourceType(ctx, resourceType), d, meta)
+	}
+}
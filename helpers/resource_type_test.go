@@ -0,0 +1,43 @@
+package helpers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/harness/terraform-provider-harness/internal"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyResourceType(t *testing.T) {
+	var got []string
+	record := func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		got = append(got, internal.GetResourceType(ctx))
+		return nil
+	}
+	importer := &schema.ResourceImporter{
+		StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+			got = append(got, internal.GetResourceType(ctx))
+			return []*schema.ResourceData{d}, nil
+		},
+	}
+
+	resources := map[string]*schema.Resource{
+		"harness_platform_a": {ReadContext: record, DeleteContext: record, Importer: importer},
+		"harness_platform_b": {ReadContext: record, Importer: importer},
+	}
+	ApplyResourceType(resources, "data.")
+
+	a, b := resources["harness_platform_a"], resources["harness_platform_b"]
+	require.Nil(t, a.CreateContext)
+
+	a.ReadContext(context.Background(), nil, nil)
+	a.DeleteContext(context.Background(), nil, nil)
+	b.ReadContext(context.Background(), nil, nil)
+	a.Importer.StateContext(context.Background(), nil, nil)
+	b.Importer.StateContext(context.Background(), nil, nil)
+
+	require.Equal(t, []string{"data.harness_platform_a", "data.harness_platform_a", "data.harness_platform_b", "data.harness_platform_a", "data.harness_platform_b"}, got)
+	require.Empty(t, internal.GetResourceType(context.Background()))
+}
@@ -1,9 +1,6 @@
 package helpers
 
 import (
-	"fmt"
-	"strings"
-
 	"github.com/antihax/optional"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -25,6 +22,14 @@ func SetSchemaFlagType(s *schema.Schema, flag SchemaFlagType) {
 	}
 }
 
+// setIdentifierValidation validates the identifier set in configuration. Computed identifiers can't
+// have a validation.
+func setIdentifierValidation(s *schema.Schema, flag SchemaFlagType) {
+	if flag != SchemaFlagTypes.Computed {
+		s.ValidateDiagFunc = ValidateIdentifier
+	}
+}
+
 func GetTagsSchema(flag SchemaFlagType) *schema.Schema {
 	s := &schema.Schema{
 		Description: "Tags to associate with the resource. Tags should be in the form `name:value`.",
@@ -48,6 +53,7 @@ func GetIdentifierSchema(flag SchemaFlagType) *schema.Schema {
 	}
 
 	SetSchemaFlagType(s, flag)
+	setIdentifierValidation(s, flag)
 
 	return s
 }
@@ -58,6 +64,7 @@ func GetProjectIdSchema(flag SchemaFlagType) *schema.Schema {
 		Type:        schema.TypeString,
 	}
 	SetSchemaFlagType(s, flag)
+	setIdentifierValidation(s, flag)
 	return s
 }
 
@@ -67,6 +74,7 @@ func GetOrgIdSchema(flag SchemaFlagType) *schema.Schema {
 		Type:        schema.TypeString,
 	}
 	SetSchemaFlagType(s, flag)
+	setIdentifierValidation(s, flag)
 	return s
 }
 
@@ -149,169 +157,74 @@ func BuildField(d *schema.ResourceData, field string) optional.String {
 }
 
 // PipelineResourceImporter defines the importer configuration for all pipeline level resources.
-var PipelineResourceImporter = &schema.ResourceImporter{
-	State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-		parts := strings.Split(d.Id(), "/")
-		d.Set("org_id", parts[0])
-		d.Set("project_id", parts[1])
-		d.Set("pipeline_id", parts[2])
-		d.Set("identifier", parts[3])
-		d.SetId(parts[3])
-
-		return []*schema.ResourceData{d}, nil
-	},
-}
-
-var TriggerResourceImporter = &schema.ResourceImporter{
-	State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-		parts := strings.Split(d.Id(), "/")
-		d.Set("org_id", parts[0])
-		d.Set("project_id", parts[1])
-		d.Set("target_id", parts[2])
-		d.Set("identifier", parts[3])
-		d.SetId(parts[3])
-
-		return []*schema.ResourceData{d}, nil
-	},
-}
-
-var EnvRelatedResourceImporter = &schema.ResourceImporter{
-	State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-		parts := strings.Split(d.Id(), "/")
-		d.Set("org_id", parts[0])
-		d.Set("project_id", parts[1])
-		d.Set("env_id", parts[2])
-		d.Set("identifier", parts[3])
-		d.SetId(parts[3])
-
-		return []*schema.ResourceData{d}, nil
-	},
+// The id used for the import should be in the format <org_id>/<project_id>/<pipeline_id>/<identifier>.
+var PipelineResourceImporter = (&ImportIdParser{
+	Formats: []ImportIdFormat{{"org_id", "project_id", "pipeline_id", "identifier"}},
+}).Importer()
+
+// TriggerResourceImporter defines the importer configuration for triggers.
+// The id used for the import should be in the format <org_id>/<project_id>/<target_id>/<identifier>.
+var TriggerResourceImporter = (&ImportIdParser{
+	Formats: []ImportIdFormat{{"org_id", "project_id", "target_id", "identifier"}},
+}).Importer()
+
+// EnvRelatedResourceImporter defines the importer configuration for all environment level resources.
+// The id used for the import should be in the format <org_id>/<project_id>/<env_id>/<identifier>.
+var EnvRelatedResourceImporter = (&ImportIdParser{
+	Formats: []ImportIdFormat{{"org_id", "project_id", "env_id", "identifier"}},
+}).Importer()
+
+// ProjectResourceImportIdParser parses the import ids of project level resources.
+// The id used for the import should be in the format <org_id>/<project_id>/<identifier>. When the provider
+// has default_org_id and default_project_id configured, <project_id>/<identifier> and <identifier> are also accepted.
+var ProjectResourceImportIdParser = &ImportIdParser{
+	Formats:  []ImportIdFormat{{"org_id", "project_id", "identifier"}, {"project_id", "identifier"}, {"identifier"}},
+	Required: []string{"org_id", "project_id"},
 }
 
 // ProjectResourceImporter defines the importer configuration for all project level resources.
-// The id used for the import should be in the format <org_id>/<project_id>/<identifier>
-var ProjectResourceImporter = &schema.ResourceImporter{
-	State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-		parts := strings.Split(d.Id(), "/")
-		d.Set("org_id", parts[0])
-		d.Set("project_id", parts[1])
-		d.Set("identifier", parts[2])
-		d.SetId(parts[2])
-
-		return []*schema.ResourceData{d}, nil
-	},
-}
+var ProjectResourceImporter = ProjectResourceImportIdParser.Importer()
 
 // GitopsAgentResourceImporter defines the importer configuration for all project level gitops agent resources.
-// The id used for the import should be in the format <org_id>/<project_id>/<identifier>/<agentId>
-var GitopsAgentResourceImporter = &schema.ResourceImporter{
-	State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-		parts := strings.Split(d.Id(), "/")
-		if len(parts) == 2 { //Account level
-			d.Set("agent_id", parts[0])
-			d.Set("identifier", parts[1])
-			d.SetId(parts[1])
-			return []*schema.ResourceData{d}, nil
-		}
-
-		if len(parts) == 4 { //Project level
-			d.Set("org_id", parts[0])
-			d.Set("project_id", parts[1])
-			d.Set("agent_id", parts[2])
-			d.Set("identifier", parts[3])
-			d.SetId(parts[3])
-			return []*schema.ResourceData{d}, nil
-		}
-
-		return nil, fmt.Errorf("invalid identifier: %s", d.Id())
-	},
+// The id used for the import should be in the format <org_id>/<project_id>/<agent_id>/<identifier>, or
+// <agent_id>/<identifier> for account level resources.
+var GitopsAgentResourceImporter = (&ImportIdParser{
+	Formats: []ImportIdFormat{{"org_id", "project_id", "agent_id", "identifier"}, {"agent_id", "identifier"}},
+}).Importer()
+
+// OrgResourceImportIdParser parses the import ids of organization level resources.
+// The id used for the import should be in the format <org_id>/<identifier>. When the provider has
+// default_org_id configured, <identifier> is also accepted.
+var OrgResourceImportIdParser = &ImportIdParser{
+	Formats:  []ImportIdFormat{{"org_id", "identifier"}, {"identifier"}},
+	Required: []string{"org_id"},
 }
 
 // OrgResourceImporter defines the importer configuration for all organization level resources.
-// The id used for the import should be in the format <org_id>/<identifier>
-var OrgResourceImporter = &schema.ResourceImporter{
-	State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-		parts := strings.Split(d.Id(), "/")
-		d.Set("org_id", parts[0])
-		d.Set("identifier", parts[1])
-		d.SetId(parts[1])
-
-		return []*schema.ResourceData{d}, nil
-	},
+var OrgResourceImporter = OrgResourceImportIdParser.Importer()
+
+// AccountResourceImportIdParser parses the import ids of account level resources, which are just the
+// identifier.
+var AccountResourceImportIdParser = &ImportIdParser{
+	Formats: []ImportIdFormat{{"identifier"}},
 }
 
-// MultiLevelResourceImporter defines the importer configuration for all multi level resources.
+// MultiLevelResourceImportIdParser parses the import ids of multi level resources.
 // The format used for the id is as follows:
 //   - Account Level: <identifier>
 //   - Org Level: <org_id>/<identifier>
 //   - Project Level: <org_id>/<project_id>/<identifier>
-var MultiLevelResourceImporter = &schema.ResourceImporter{
-	State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-		parts := strings.Split(d.Id(), "/")
-
-		partCount := len(parts)
-		isAccountConnector := partCount == 1
-		isOrgConnector := partCount == 2
-		isProjectConnector := partCount == 3
-
-		if isAccountConnector {
-			d.SetId(parts[0])
-			d.Set("identifier", parts[0])
-			return []*schema.ResourceData{d}, nil
-		}
-
-		if isOrgConnector {
-			d.SetId(parts[1])
-			d.Set("identifier", parts[1])
-			d.Set("org_id", parts[0])
-			return []*schema.ResourceData{d}, nil
-		}
-
-		if isProjectConnector {
-			d.SetId(parts[2])
-			d.Set("identifier", parts[2])
-			d.Set("project_id", parts[1])
-			d.Set("org_id", parts[0])
-			return []*schema.ResourceData{d}, nil
-		}
-
-		return nil, fmt.Errorf("invalid identifier: %s", d.Id())
-	},
+//
+// The parser doesn't use the provider's default_org_id and default_project_id. ApplyScopeDefaults looks
+// entities imported by <identifier> up in the default scope before the account.
+var MultiLevelResourceImportIdParser = &ImportIdParser{
+	Formats: []ImportIdFormat{{"org_id", "project_id", "identifier"}, {"org_id", "identifier"}, {"identifier"}},
 }
 
-var MultiLevelFilterImporter = &schema.ResourceImporter{
-	State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-		parts := strings.Split(d.Id(), "/")
-
-		partCount := len(parts)
-		isAccountConnector := partCount == 2
-		isOrgConnector := partCount == 3
-		isProjectConnector := partCount == 4
-
-		if isAccountConnector {
-			d.SetId(parts[0])
-			d.Set("identifier", parts[0])
-			d.Set("type", parts[1])
-			return []*schema.ResourceData{d}, nil
-		}
-
-		if isOrgConnector {
-			d.SetId(parts[1])
-			d.Set("identifier", parts[1])
-			d.Set("org_id", parts[0])
-			d.Set("type", parts[2])
-			return []*schema.ResourceData{d}, nil
-		}
-
-		if isProjectConnector {
-			d.SetId(parts[2])
-			d.Set("identifier", parts[2])
-			d.Set("project_id", parts[1])
-			d.Set("org_id", parts[0])
-			d.Set("type", parts[3])
-			return []*schema.ResourceData{d}, nil
-		}
-
-		return nil, fmt.Errorf("invalid identifier: %s", d.Id())
-	},
-}
+// MultiLevelResourceImporter defines the importer configuration for all multi level resources.
+var MultiLevelResourceImporter = MultiLevelResourceImportIdParser.Importer()
+
+// MultiLevelFilterImporter defines the importer configuration for filters, whose ids end with the filter type.
+var MultiLevelFilterImporter = (&ImportIdParser{
+	Formats: []ImportIdFormat{{"org_id", "project_id", "identifier", "type"}, {"org_id", "identifier", "type"}, {"identifier", "type"}},
+}).Importer()
@@ -0,0 +1,243 @@
+package helpers
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/harness/terraform-provider-harness/internal"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// scopeDefaults maps each scope attribute to the provider argument that supplies its default.
+var scopeDefaults = []struct {
+	Key         string
+	ProviderArg string
+}{
+	{Key: "org_id", ProviderArg: "default_org_id"},
+	{Key: "project_id", ProviderArg: "default_project_id"},
+}
+
+// GetScopeDefaults returns the provider level default org and project identifiers.
+func GetScopeDefaults(meta interface{}) (orgId string, projectId string) {
+	if session, ok := meta.(*internal.Session); ok && session != nil {
+		return session.DefaultOrgId, session.DefaultProjectId
+	}
+	return "", ""
+}
+
+func getScopeDefault(meta interface{}, key string) string {
+	orgId, projectId := GetScopeDefaults(meta)
+	if key == "org_id" {
+		return orgId
+	}
+	return projectId
+}
+
+// ApplyScopeDefaults allows the `org_id` and `project_id` attributes of platform resources to fall
+// back to the `default_org_id` and `default_project_id` provider arguments. The effective value is
+// set during plan so it is recorded in state and doesn't produce a diff on later plans. Multi level
+// resources only use the defaults when their configuration sets neither attribute, so configuring
+// one of them still selects the organization scope, and resources imported by identifier alone are
+// looked up in the default scope before the account.
+func ApplyScopeDefaults(resources map[string]*schema.Resource) {
+	for _, r := range resources {
+		managed := map[string]bool{}
+		forceNew := []string{}
+		multiLevel := isMultiLevel(r.Schema)
+
+		for _, def := range scopeDefaults {
+			s, ok := r.Schema[def.Key]
+			if !ok || s.Computed {
+				continue
+			}
+			forceNew = append(forceNew, def.Key)
+
+			if s.Required {
+				managed[def.Key] = true
+				s.Required = false
+				s.Optional = true
+				s.RequiredWith = nil
+			} else if !multiLevel {
+				continue
+			}
+			s.Computed = true
+		}
+
+		if len(forceNew) == 0 {
+			continue
+		}
+
+		if s, ok := r.Schema["identifier"]; ok && !s.ForceNew && (s.Optional || s.Required) {
+			forceNew = append(forceNew, "identifier")
+		}
+
+		defaultsCustomizeDiff := scopeDefaultsCustomizeDiff(managed)
+		if multiLevel {
+			defaultsCustomizeDiff = multiLevelScopeDefaultsCustomizeDiff
+			if r.Importer != nil && r.Importer.StateContext != nil && r.ReadContext != nil {
+				r.Importer = withScopeDefaultsImport(r)
+			}
+		}
+
+		customizeDiff := customdiff.Sequence(defaultsCustomizeDiff, ScopeForceNewCustomizeDiff(forceNew...))
+		if r.CustomizeDiff != nil {
+			r.CustomizeDiff = customdiff.Sequence(customizeDiff, r.CustomizeDiff)
+		} else {
+			r.CustomizeDiff = customizeDiff
+		}
+	}
+}
+
+// isMultiLevel reports whether a schema has the optional `org_id` and `project_id` attributes of
+// resources that can be created at the account, organization or project scope.
+func isMultiLevel(s map[string]*schema.Schema) bool {
+	for _, def := range scopeDefaults {
+		if v, ok := s[def.Key]; !ok || !v.Optional || v.Computed {
+			return false
+		}
+	}
+	return true
+}
+
+// multiLevelScopeDefaults returns the default scope of multi level resources, which is the account
+// when the provider has no default organization.
+func multiLevelScopeDefaults(meta interface{}) (orgId string, projectId string) {
+	orgId, projectId = GetScopeDefaults(meta)
+	if orgId == "" {
+		return "", ""
+	}
+	return orgId, projectId
+}
+
+// ScopeForceNewCustomizeDiff requires the replacement of a resource when one of the given scope or
+// identifier attributes changes. Harness entities can't be moved, so updating them in place would be
+// sent to the new scope and either fail or create a copy while the original is left behind.
+func ScopeForceNewCustomizeDiff(keys ...string) schema.CustomizeDiffFunc {
+	return func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+		if d.Id() == "" {
+			return nil
+		}
+
+		for _, key := range keys {
+			if !d.HasChange(key) {
+				continue
+			}
+			if err := d.ForceNew(key); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+}
+
+func scopeDefaultsCustomizeDiff(managed map[string]bool) schema.CustomizeDiffFunc {
+	return func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+		config := d.GetRawConfig()
+		if config.IsNull() || !config.IsKnown() {
+			return nil
+		}
+
+		for _, def := range scopeDefaults {
+			if !managed[def.Key] {
+				continue
+			}
+
+			v := config.GetAttr(def.Key)
+			if !v.IsKnown() || !v.IsNull() {
+				continue
+			}
+
+			// Resources that exist without the attribute keep it empty, so configuring a default on the
+			// provider doesn't move them and require their replacement.
+			if old, _ := d.GetChange(def.Key); d.Id() != "" && old.(string) == "" {
+				continue
+			}
+
+			value := getScopeDefault(meta, def.Key)
+			if value == "" {
+				return fmt.Errorf("%s: required field is not set and no %s is configured on the provider", def.Key, def.ProviderArg)
+			}
+
+			if err := d.SetNew(def.Key, value); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+}
+
+// multiLevelScopeDefaultsCustomizeDiff sets the scope attributes of multi level resources that aren't
+// configured. They take the provider defaults when neither is configured, and are empty otherwise.
+func multiLevelScopeDefaultsCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	config := d.GetRawConfig()
+	if config.IsNull() || !config.IsKnown() {
+		return nil
+	}
+
+	orgConfig, projectConfig := config.GetAttr("org_id"), config.GetAttr("project_id")
+	if !orgConfig.IsKnown() || !projectConfig.IsKnown() {
+		return nil
+	}
+
+	var values map[string]string
+	if orgConfig.IsNull() && projectConfig.IsNull() {
+		orgId, projectId := multiLevelScopeDefaults(meta)
+		values = map[string]string{"org_id": orgId, "project_id": projectId}
+
+		// Resources that exist at the account scope stay there, so configuring defaults on the
+		// provider doesn't require their replacement.
+		if old, _ := d.GetChange("org_id"); d.Id() != "" && old.(string) == "" {
+			values = nil
+		}
+	}
+
+	for _, def := range scopeDefaults {
+		if !config.GetAttr(def.Key).IsNull() {
+			continue
+		}
+		if err := d.SetNew(def.Key, values[def.Key]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// withScopeDefaultsImport returns the importer of a multi level resource that imports entities given
+// by their identifier alone from the provider's default scope when they exist there, and from the
+// account otherwise.
+func withScopeDefaultsImport(r *schema.Resource) *schema.ResourceImporter {
+	importer := *r.Importer
+	importState, read := importer.StateContext, r.ReadContext
+
+	importer.StateContext = func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+		results, err := importState(ctx, d, meta)
+		if err != nil || len(results) != 1 {
+			return results, err
+		}
+
+		d = results[0]
+		orgId, projectId := multiLevelScopeDefaults(meta)
+		if orgId == "" || d.Get("org_id").(string) != "" || d.Get("project_id").(string) != "" {
+			return results, nil
+		}
+
+		scoped := r.Data(d.State())
+		scoped.Set("org_id", orgId)
+		scoped.Set("project_id", projectId)
+		if diags := read(ctx, scoped, meta); diags.HasError() || scoped.Id() == "" {
+			log.Printf("[DEBUG] %s doesn't exist in the default scope, importing it from the account", d.Id())
+			return results, nil
+		}
+
+		d.Set("org_id", orgId)
+		d.Set("project_id", projectId)
+		return results, nil
+	}
+
+	return &importer
+}
@@ -0,0 +1,248 @@
+package helpers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/harness/terraform-provider-harness/internal"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/require"
+)
+
+func testScopeResource(setSchema func(map[string]*schema.Schema)) *schema.Resource {
+	r := &schema.Resource{Schema: map[string]*schema.Schema{}}
+	setSchema(r.Schema)
+	ApplyScopeDefaults(map[string]*schema.Resource{"test": r})
+	return r
+}
+
+// testDiff plans a new resource with the given configuration.
+func testDiff(t *testing.T, r *schema.Resource, config map[string]interface{}, meta interface{}) (*terraform.InstanceDiff, error) {
+	return testUpdateDiff(t, r, nil, config, meta)
+}
+
+// testUpdateDiff plans a resource with the given state attributes and configuration. The resource is
+// new when state is nil.
+func testUpdateDiff(t *testing.T, r *schema.Resource, state map[string]string, config map[string]interface{}, meta interface{}) (*terraform.InstanceDiff, error) {
+	attrs := map[string]cty.Value{}
+	for name, ty := range r.CoreConfigSchema().ImpliedType().AttributeTypes() {
+		if v, ok := config[name]; ok {
+			attrs[name] = cty.StringVal(v.(string))
+		} else {
+			attrs[name] = cty.NullVal(ty)
+		}
+	}
+
+	instance := &terraform.InstanceState{RawConfig: cty.ObjectVal(attrs)}
+	if state != nil {
+		instance.ID = state["id"]
+		instance.Attributes = state
+	}
+	return r.SimpleDiff(context.Background(), instance, terraform.NewResourceConfigRaw(config), meta)
+}
+
+func TestApplyScopeDefaultsProjectLevel(t *testing.T) {
+	r := testScopeResource(SetProjectLevelResourceSchema)
+	require.False(t, r.Schema["org_id"].Required)
+	require.True(t, r.Schema["org_id"].Computed)
+
+	session := &internal.Session{DefaultOrgId: "default_org", DefaultProjectId: "default_project"}
+	config := map[string]interface{}{"identifier": "test", "name": "test"}
+
+	diff, err := testDiff(t, r, config, session)
+	require.NoError(t, err)
+	require.Equal(t, "default_org", diff.Attributes["org_id"].New)
+	require.Equal(t, "default_project", diff.Attributes["project_id"].New)
+
+	config["project_id"] = "explicit_project"
+	diff, err = testDiff(t, r, config, session)
+	require.NoError(t, err)
+	require.Equal(t, "default_org", diff.Attributes["org_id"].New)
+	require.Equal(t, "explicit_project", diff.Attributes["project_id"].New)
+
+	_, err = testDiff(t, r, map[string]interface{}{"identifier": "test", "name": "test"}, &internal.Session{})
+	require.ErrorContains(t, err, "default_org_id")
+}
+
+func TestApplyScopeDefaultsMultiLevel(t *testing.T) {
+	r := testScopeResource(SetMultiLevelResourceSchema)
+	require.True(t, r.Schema["org_id"].Optional)
+	require.True(t, r.Schema["org_id"].Computed)
+
+	config := map[string]interface{}{"identifier": "test", "name": "test"}
+	session := &internal.Session{DefaultOrgId: "default_org", DefaultProjectId: "default_project"}
+
+	diff, err := testDiff(t, r, config, session)
+	require.NoError(t, err)
+	require.Equal(t, "default_org", diff.Attributes["org_id"].New)
+	require.Equal(t, "default_project", diff.Attributes["project_id"].New)
+
+	// Without defaults the resource is created at the account scope.
+	diff, err = testDiff(t, r, config, &internal.Session{})
+	require.NoError(t, err)
+	require.Empty(t, diff.Attributes["org_id"].New)
+	require.Empty(t, diff.Attributes["project_id"].New)
+
+	// Setting org_id selects the organization scope, without the default project.
+	config["org_id"] = "org"
+	diff, err = testDiff(t, r, config, session)
+	require.NoError(t, err)
+	require.Equal(t, "org", diff.Attributes["org_id"].New)
+	require.Empty(t, diff.Attributes["project_id"].New)
+
+	// Removing the scope of an existing resource moves it to the default scope.
+	state := map[string]string{"id": "test", "identifier": "test", "name": "test", "org_id": "org", "project_id": "project"}
+	diff, err = testUpdateDiff(t, r, state, map[string]interface{}{"identifier": "test", "name": "test"}, session)
+	require.NoError(t, err)
+	require.Equal(t, "default_org", diff.Attributes["org_id"].New)
+	require.True(t, diff.Attributes["org_id"].RequiresNew)
+}
+
+func TestApplyScopeDefaultsMultiLevelImport(t *testing.T) {
+	var reads []string
+	r := &schema.Resource{
+		Schema:   map[string]*schema.Schema{},
+		Importer: MultiLevelResourceImporter,
+		ReadContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			reads = append(reads, d.Get("org_id").(string)+"/"+d.Get("project_id").(string)+"/"+d.Id())
+			if d.Id() != "in_project" {
+				d.SetId("")
+			}
+			return nil
+		},
+	}
+	SetMultiLevelResourceSchema(r.Schema)
+	ApplyScopeDefaults(map[string]*schema.Resource{"test": r})
+	session := &internal.Session{DefaultOrgId: "default_org", DefaultProjectId: "default_project"}
+
+	importState := func(id string, meta interface{}) *schema.ResourceData {
+		d := r.TestResourceData()
+		d.SetId(id)
+		results, err := r.Importer.StateContext(context.Background(), d, meta)
+		require.NoError(t, err)
+		return results[0]
+	}
+
+	// Identifiers alone are looked up in the default scope first.
+	d := importState("in_project", session)
+	require.Equal(t, "default_org", d.Get("org_id"))
+	require.Equal(t, "default_project", d.Get("project_id"))
+
+	// And imported from the account when they aren't there.
+	d = importState("in_account", session)
+	require.Empty(t, d.Get("org_id"))
+	require.Empty(t, d.Get("project_id"))
+	require.Equal(t, []string{"default_org/default_project/in_project", "default_org/default_project/in_account"}, reads)
+
+	// IDs with a scope and providers without defaults don't look anything up.
+	d = importState("org/in_project", session)
+	require.Equal(t, "org", d.Get("org_id"))
+	require.Empty(t, d.Get("project_id"))
+	importState("in_project", &internal.Session{})
+	require.Len(t, reads, 2)
+}
+
+func TestScopeForceNew(t *testing.T) {
+	r := testScopeResource(SetMultiLevelResourceSchema)
+	state := map[string]string{"id": "test", "identifier": "test", "name": "test", "org_id": "org", "project_id": "project"}
+	config := map[string]interface{}{"identifier": "test", "name": "test", "org_id": "org", "project_id": "project"}
+
+	diff, err := testUpdateDiff(t, r, state, config, &internal.Session{})
+	require.NoError(t, err)
+	require.True(t, diff.Empty())
+
+	// Renames are updated in place.
+	config["name"] = "renamed"
+	diff, err = testUpdateDiff(t, r, state, config, &internal.Session{})
+	require.NoError(t, err)
+	require.False(t, diff.RequiresNew())
+
+	config["project_id"] = "other_project"
+	diff, err = testUpdateDiff(t, r, state, config, &internal.Session{})
+	require.NoError(t, err)
+	require.True(t, diff.RequiresNew())
+	require.True(t, diff.Attributes["project_id"].RequiresNew)
+
+	// Moving to the account or to the default org is also a scope change.
+	diff, err = testUpdateDiff(t, r, state, map[string]interface{}{"identifier": "test", "name": "test"}, &internal.Session{})
+	require.NoError(t, err)
+	require.True(t, diff.Attributes["org_id"].RequiresNew)
+
+	// Provider defaults don't apply to multi level resources, so they don't move them either.
+	state = map[string]string{"id": "test", "identifier": "test", "name": "test"}
+	diff, err = testUpdateDiff(t, r, state, map[string]interface{}{"identifier": "test", "name": "test"}, &internal.Session{DefaultOrgId: "default_org"})
+	require.NoError(t, err)
+	require.True(t, diff.Empty())
+}
+
+func TestScopeForceNewDefaults(t *testing.T) {
+	r := testScopeResource(SetProjectLevelResourceSchema)
+	session := &internal.Session{DefaultOrgId: "default_org", DefaultProjectId: "default_project"}
+	config := map[string]interface{}{"identifier": "test", "name": "test"}
+
+	// Configuring defaults doesn't replace resources that exist without a scope.
+	state := map[string]string{"id": "test", "identifier": "test", "name": "test", "org_id": "", "project_id": ""}
+	diff, err := testUpdateDiff(t, r, state, config, session)
+	require.NoError(t, err)
+	require.True(t, diff.Empty())
+
+	// Defaults recorded in state don't produce a diff.
+	state = map[string]string{"id": "test", "identifier": "test", "name": "test", "org_id": "default_org", "project_id": "default_project"}
+	diff, err = testUpdateDiff(t, r, state, config, session)
+	require.NoError(t, err)
+	require.True(t, diff.Empty())
+
+	// Removing an explicit scope in favour of a different default moves the resource.
+	state = map[string]string{"id": "test", "identifier": "test", "name": "test", "org_id": "org", "project_id": "default_project"}
+	diff, err = testUpdateDiff(t, r, state, config, session)
+	require.NoError(t, err)
+	require.Equal(t, "default_org", diff.Attributes["org_id"].New)
+	require.True(t, diff.Attributes["org_id"].RequiresNew)
+}
+
+func TestScopeForceNewIdentifier(t *testing.T) {
+	// Identifiers that aren't already ForceNew in the schema also require a replacement.
+	r := testScopeResource(func(s map[string]*schema.Schema) {
+		SetProjectLevelResourceSchema(s)
+		s["identifier"] = &schema.Schema{Type: schema.TypeString, Optional: true, Computed: true}
+	})
+	state := map[string]string{"id": "test", "identifier": "test", "name": "test", "org_id": "org", "project_id": "project"}
+	config := map[string]interface{}{"identifier": "other", "name": "test", "org_id": "org", "project_id": "project"}
+
+	diff, err := testUpdateDiff(t, r, state, config, &internal.Session{})
+	require.NoError(t, err)
+	require.True(t, diff.Attributes["identifier"].RequiresNew)
+
+	// New resources aren't affected.
+	diff, err = testDiff(t, r, config, &internal.Session{})
+	require.NoError(t, err)
+	require.False(t, diff.Attributes["identifier"].RequiresNew)
+}
+
+func TestProjectResourceImporterDefaults(t *testing.T) {
+	r := testScopeResource(SetProjectLevelResourceSchema)
+	session := &internal.Session{DefaultOrgId: "default_org", DefaultProjectId: "default_project"}
+
+	for id, expected := range map[string][]string{
+		"org/project/test": {"org", "project"},
+		"project/test":     {"default_org", "project"},
+		"test":             {"default_org", "default_project"},
+	} {
+		d := r.TestResourceData()
+		d.SetId(id)
+
+		result, err := ProjectResourceImporter.StateContext(context.Background(), d, session)
+		require.NoError(t, err)
+		require.Equal(t, "test", result[0].Id())
+		require.Equal(t, expected[0], result[0].Get("org_id"))
+		require.Equal(t, expected[1], result[0].Get("project_id"))
+	}
+
+	d := r.TestResourceData()
+	d.SetId("test")
+	_, err := ProjectResourceImporter.StateContext(context.Background(), d, &internal.Session{})
+	require.Error(t, err)
+}
@@ -1,16 +1,26 @@
 package helpers
 
 import (
+	"context"
+	"fmt"
 	"strings"
 
 	"github.com/harness/harness-go-sdk/harness/nextgen"
+	"github.com/harness/terraform-provider-harness/internal"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"gopkg.in/yaml.v3"
 )
 
+// ExpandTags parses tags in the form `name:value`. Only the first colon separates the name from the
+// value, so values such as URLs may contain colons.
 func ExpandTags(tags []interface{}) map[string]string {
 	result := map[string]string{}
 
 	for _, tag := range tags {
-		parts := strings.Split(tag.(string), ":")
+		parts := strings.SplitN(tag.(string), ":", 2)
 		if len(parts) == 1 {
 			parts = append(parts, "")
 		}
@@ -65,3 +75,271 @@ func ExpandField(permissions []interface{}) []string {
 
 // 	return result
 // }
+
+// ExpandTagsMap converts the value of a `tags_map` attribute.
+func ExpandTagsMap(tags map[string]interface{}) map[string]string {
+	result := map[string]string{}
+	for k, v := range tags {
+		result[k] = v.(string)
+	}
+	return result
+}
+
+func getDefaultTags(meta interface{}) map[string]string {
+	if session, ok := meta.(*internal.Session); ok && session != nil {
+		return session.DefaultTags
+	}
+	return nil
+}
+
+// MergeTags returns the default tags overridden by the resource tags.
+func MergeTags(defaults map[string]string, tags map[string]string) map[string]string {
+	result := map[string]string{}
+	for k, v := range defaults {
+		result[k] = v
+	}
+	for k, v := range tags {
+		result[k] = v
+	}
+	return result
+}
+
+// RemoveDefaultTags removes the tags inherited from the defaults. Tags that are also part of
+// the resource configuration are kept.
+func RemoveDefaultTags(tags map[string]string, defaults map[string]string, configured map[string]string) map[string]string {
+	result := map[string]string{}
+	for k, v := range tags {
+		if dv, ok := defaults[k]; ok && dv == v {
+			if cv, ok := configured[k]; !ok || cv != v {
+				continue
+			}
+		}
+		result[k] = v
+	}
+	return result
+}
+
+// yamlTaggedResources are the platform resources whose tags are part of their YAML and aren't
+// sent from the `tags` attribute, so default tags can't be added to them.
+var yamlTaggedResources = map[string]bool{
+	"harness_platform_input_set": true,
+	"harness_platform_pipeline":  true,
+	"harness_platform_triggers":  true,
+}
+
+// yamlTagsRootKeys are the root keys of the YAML of the platform resources whose tags can be set both
+// with `tags` and in their YAML. The tags from the YAML are part of `tags_all` and are sent along with
+// the other tags.
+var yamlTagsRootKeys = map[string]string{
+	"harness_platform_environment":    "environment",
+	"harness_platform_infrastructure": "infrastructureDefinition",
+	"harness_platform_service":        "service",
+}
+
+// StateTypes returns the state types of the given resources. The provider takes them before it
+// wraps the resources, so they describe the state written by earlier versions, which doesn't hold
+// the attributes and blocks added by the wrappers.
+func StateTypes(resources map[string]*schema.Resource) map[string]cty.Type {
+	types := make(map[string]cty.Type, len(resources))
+	for name, r := range resources {
+		types[name] = r.CoreConfigSchema().ImpliedType()
+	}
+	return types
+}
+
+// ApplyDefaultTags merges the provider's `default_tags` into the tags sent by the given Next Gen
+// resources. stateTypes are the types of the resources before they were wrapped, see StateTypes.
+// The merged tags are exposed in the computed `tags_all` attribute while `tags` only holds the
+// tags set on the resource itself. Resources also get a `tags_map` attribute, which holds the tags
+// as a map instead and is merged into `tags` before the resource sees them.
+func ApplyDefaultTags(resources map[string]*schema.Resource, stateTypes map[string]cty.Type) {
+	for name, r := range resources {
+		if yamlTaggedResources[name] {
+			continue
+		}
+
+		s, ok := r.Schema["tags"]
+		if !ok || s.Computed || s.Type != schema.TypeSet {
+			continue
+		}
+
+		r.Schema["tags_all"] = &schema.Schema{
+			Description: "Tags of the resource, including those inherited from the provider `default_tags`.",
+			Type:        schema.TypeSet,
+			Computed:    true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		}
+
+		// States written before `tags_map` was added are upgraded from version 0. The type of the
+		// previous schema is only used for states in the legacy flatmap format.
+		if r.SchemaVersion == 0 {
+			r.SchemaVersion = 1
+			r.StateUpgraders = []schema.StateUpgrader{{
+				Version: 0,
+				Type:    stateTypes[name],
+				Upgrade: upgradeTagsStateV0,
+			}}
+		}
+
+		r.Schema["tags_map"] = &schema.Schema{
+			Description:   "Tags to associate with the resource, as a map of tag names to values. This is the recommended way to set tags and can't be used together with `tags`.",
+			Type:          schema.TypeMap,
+			Optional:      true,
+			ConflictsWith: []string{"tags"},
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		}
+
+		rootKey := yamlTagsRootKeys[name]
+		if r.CustomizeDiff != nil {
+			r.CustomizeDiff = customdiff.Sequence(tagsAllCustomizeDiff(rootKey), r.CustomizeDiff)
+		} else {
+			r.CustomizeDiff = tagsAllCustomizeDiff(rootKey)
+		}
+
+		r.CreateContext = withDefaultTags(r.CreateContext, true, rootKey)
+		r.UpdateContext = withDefaultTags(r.UpdateContext, true, rootKey)
+		r.ReadContext = withDefaultTags(r.ReadContext, false, rootKey)
+	}
+}
+
+// upgradeTagsStateV0 rewrites the tags stored by earlier versions in the form written by FlattenTags,
+// e.g. `name:` becomes `name`, so every state holds tags in the same form.
+func upgradeTagsStateV0(ctx context.Context, rawState map[string]interface{}, meta interface{}) (map[string]interface{}, error) {
+	if rawState == nil {
+		return rawState, nil
+	}
+
+	for _, attr := range []string{"tags", "tags_all"} {
+		tags, ok := rawState[attr].([]interface{})
+		if !ok {
+			continue
+		}
+
+		var values []interface{}
+		for _, tag := range tags {
+			if tag, ok := tag.(string); ok {
+				values = append(values, tag)
+			}
+		}
+
+		rawState[attr] = FlattenTags(ExpandTags(values))
+	}
+
+	return rawState, nil
+}
+
+// tagsAllCustomizeDiff plans `tags_all`. rootKey is the root key of the YAML of resources whose
+// YAML can hold tags too, and empty for other resources.
+func tagsAllCustomizeDiff(rootKey string) schema.CustomizeDiffFunc {
+	return func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+		yamlTags, known, err := configuredYamlTags(d.GetRawConfig(), rootKey)
+		if err != nil {
+			return err
+		}
+
+		if !d.NewValueKnown("tags") || !d.NewValueKnown("tags_map") || !known {
+			return d.SetNewComputed("tags_all")
+		}
+
+		tags := MergeTags(ExpandTags(d.Get("tags").(*schema.Set).List()), ExpandTagsMap(d.Get("tags_map").(map[string]interface{})))
+		return d.SetNew("tags_all", FlattenTags(MergeTags(MergeTags(getDefaultTags(meta), yamlTags), tags)))
+	}
+}
+
+// configuredYamlTags returns the tags below rootKey in the configured `yaml` and whether they are
+// known. Harness generates the YAML of resources that don't configure it from the attributes, so it
+// only adds tags when it is configured.
+func configuredYamlTags(config cty.Value, rootKey string) (map[string]string, bool, error) {
+	if rootKey == "" || config.IsNull() || !config.IsKnown() || !config.Type().HasAttribute("yaml") {
+		return nil, true, nil
+	}
+
+	v := config.GetAttr("yaml")
+	if !v.IsKnown() {
+		return nil, false, nil
+	}
+	if v.IsNull() {
+		return nil, true, nil
+	}
+
+	tags, err := yamlTags(v.AsString(), rootKey)
+	return tags, true, err
+}
+
+// yamlTags returns the tags below rootKey in a YAML document.
+func yamlTags(doc string, rootKey string) (map[string]string, error) {
+	if rootKey == "" || strings.TrimSpace(doc) == "" {
+		return nil, nil
+	}
+
+	values := map[string]map[string]interface{}{}
+	if err := yaml.Unmarshal([]byte(doc), &values); err != nil {
+		return nil, fmt.Errorf("yaml: invalid YAML: %s", err)
+	}
+
+	tags, _ := values[rootKey]["tags"].(map[string]interface{})
+	result := map[string]string{}
+	for k, v := range tags {
+		if v != nil {
+			result[k] = fmt.Sprint(v)
+		} else {
+			result[k] = ""
+		}
+	}
+	return result, nil
+}
+
+// withDefaultTags sends the merged tags to the API and splits the tags returned by the
+// API back into `tags_all` and either `tags` or `tags_map`, whichever the resource uses. Tags of the
+// resource YAML are inherited like the default tags.
+func withDefaultTags(f func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics, merge bool, rootKey string) func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics {
+	if f == nil {
+		return nil
+	}
+
+	return func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		defaults := getDefaultTags(meta)
+		tagsMap := ExpandTagsMap(d.Get("tags_map").(map[string]interface{}))
+		configured := MergeTags(ExpandTags(d.Get("tags").(*schema.Set).List()), tagsMap)
+
+		if merge {
+			yamlTags, _, err := configuredYamlTags(d.GetRawConfig(), rootKey)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			if len(defaults) > 0 || len(tagsMap) > 0 || len(yamlTags) > 0 {
+				d.Set("tags", FlattenTags(MergeTags(MergeTags(defaults, yamlTags), configured)))
+			}
+		}
+
+		diags := f(ctx, d, meta)
+
+		if d.Id() == "" {
+			return diags
+		}
+
+		all := ExpandTags(d.Get("tags").(*schema.Set).List())
+		d.Set("tags_all", FlattenTags(all))
+
+		inherited := defaults
+		if rootKey != "" {
+			// Unlike the configuration the stored YAML can't be invalid.
+			stored, _ := yamlTags(d.Get("yaml").(string), rootKey)
+			inherited = MergeTags(defaults, stored)
+		}
+
+		own := RemoveDefaultTags(all, inherited, configured)
+		if len(tagsMap) > 0 {
+			d.Set("tags_map", own)
+			d.Set("tags", []string{})
+		} else {
+			d.Set("tags", FlattenTags(own))
+		}
+
+		return diags
+	}
+}
@@ -0,0 +1,214 @@
+package helpers
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"testing/quick"
+
+	"github.com/harness/terraform-provider-harness/internal"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExpandTags(t *testing.T) {
+	require.Equal(t, map[string]string{
+		"url":   "https://example.com:8443/path",
+		"team":  "a:b",
+		"empty": "",
+		"bare":  "",
+	}, ExpandTags([]interface{}{"url:https://example.com:8443/path", "team:a:b", "empty:", "bare"}))
+}
+
+func TestTagsRoundTrip(t *testing.T) {
+	// Tag names can't contain colons, values can contain anything.
+	expandFlattened := func(m map[string]string) bool {
+		tags := map[string]string{}
+		for k, v := range m {
+			tags[strings.ReplaceAll(k, ":", "")] = v
+		}
+
+		var flattened []interface{}
+		for _, tag := range FlattenTags(tags) {
+			flattened = append(flattened, tag)
+		}
+		return assertEqualTags(tags, ExpandTags(flattened))
+	}
+	require.NoError(t, quick.Check(expandFlattened, nil))
+
+	flattenExpanded := func(tags []string) bool {
+		var values []interface{}
+		for _, tag := range tags {
+			values = append(values, tag)
+		}
+
+		once := ExpandTags(values)
+		var again []interface{}
+		for _, tag := range FlattenTags(once) {
+			again = append(again, tag)
+		}
+		return assertEqualTags(once, ExpandTags(again))
+	}
+	require.NoError(t, quick.Check(flattenExpanded, nil))
+}
+
+func assertEqualTags(a, b map[string]string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for k, v := range a {
+		if bv, ok := b[k]; !ok || bv != v {
+			return false
+		}
+	}
+	return true
+}
+
+func TestUpgradeTagsStateV0(t *testing.T) {
+	state, err := upgradeTagsStateV0(context.Background(), map[string]interface{}{
+		"identifier": "test",
+		"tags":       []interface{}{"env:", "url:https://example.com"},
+		"tags_all":   []interface{}{"env", "team:platform"},
+	}, nil)
+	require.NoError(t, err)
+	require.Equal(t, "test", state["identifier"])
+	require.ElementsMatch(t, []string{"env", "url:https://example.com"}, state["tags"])
+	require.ElementsMatch(t, []string{"env", "team:platform"}, state["tags_all"])
+
+	state, err = upgradeTagsStateV0(context.Background(), map[string]interface{}{"identifier": "test"}, nil)
+	require.NoError(t, err)
+	require.NotContains(t, state, "tags")
+}
+
+func TestRemoveDefaultTags(t *testing.T) {
+	defaults := map[string]string{"team": "platform", "cost-center": "123"}
+	all := map[string]string{"team": "platform", "cost-center": "456", "env": "dev"}
+
+	require.Equal(t, map[string]string{"cost-center": "456", "env": "dev"}, RemoveDefaultTags(all, defaults, nil))
+	require.Equal(t, all, RemoveDefaultTags(all, defaults, map[string]string{"team": "platform"}))
+	require.Equal(t, map[string]string{"team": "platform", "cost-center": "123"}, MergeTags(defaults, nil))
+	require.Equal(t, all, MergeTags(defaults, all))
+}
+
+func TestApplyDefaultTags(t *testing.T) {
+	var sent map[string]string
+
+	r := &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"tags": GetTagsSchema(SchemaFlagTypes.Optional),
+		},
+		CreateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			sent = ExpandTags(d.Get("tags").(*schema.Set).List())
+			d.SetId("test")
+			d.Set("tags", FlattenTags(sent))
+			return nil
+		},
+	}
+
+	resources := map[string]*schema.Resource{"harness_platform_test": r}
+	ApplyDefaultTags(resources, StateTypes(resources))
+	require.Contains(t, r.Schema, "tags_all")
+
+	session := &internal.Session{DefaultTags: map[string]string{"team": "platform", "env": "prod"}}
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"tags": []interface{}{"env:dev", "owner:me"},
+	})
+
+	require.Nil(t, r.CreateContext(context.Background(), d, session))
+	require.Equal(t, map[string]string{"team": "platform", "env": "dev", "owner": "me"}, sent)
+	require.Equal(t, map[string]string{"env": "dev", "owner": "me"}, ExpandTags(d.Get("tags").(*schema.Set).List()))
+	require.Equal(t, sent, ExpandTags(d.Get("tags_all").(*schema.Set).List()))
+
+	require.Equal(t, 1, r.SchemaVersion)
+	require.Len(t, r.StateUpgraders, 1)
+	require.True(t, r.StateUpgraders[0].Type.HasAttribute("tags"))
+	require.False(t, r.StateUpgraders[0].Type.HasAttribute("tags_all"))
+	require.False(t, r.StateUpgraders[0].Type.HasAttribute("tags_map"))
+
+	// Tags set with `tags_map` are read back into it.
+	d = schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"tags_map": map[string]interface{}{"env": "dev", "url": "https://example.com"},
+	})
+
+	require.Nil(t, r.CreateContext(context.Background(), d, session))
+	require.Equal(t, map[string]string{"team": "platform", "env": "dev", "url": "https://example.com"}, sent)
+	require.Equal(t, map[string]interface{}{"env": "dev", "url": "https://example.com"}, d.Get("tags_map"))
+	require.Zero(t, d.Get("tags").(*schema.Set).Len())
+	require.Equal(t, sent, ExpandTags(d.Get("tags_all").(*schema.Set).List()))
+
+	// Tags of YAML driven resources come from the YAML.
+	pipeline := &schema.Resource{Schema: map[string]*schema.Schema{"tags": GetTagsSchema(SchemaFlagTypes.Optional)}}
+	resources = map[string]*schema.Resource{"harness_platform_pipeline": pipeline}
+	ApplyDefaultTags(resources, StateTypes(resources))
+	require.NotContains(t, pipeline.Schema, "tags_all")
+	require.NotContains(t, pipeline.Schema, "tags_map")
+	require.Zero(t, pipeline.SchemaVersion)
+}
+
+func TestApplyDefaultTagsYaml(t *testing.T) {
+	var sent map[string]string
+
+	r := &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"tags": GetTagsSchema(SchemaFlagTypes.Optional),
+			"yaml": {Type: schema.TypeString, Optional: true, Computed: true},
+		},
+		CreateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			sent = ExpandTags(d.Get("tags").(*schema.Set).List())
+			d.SetId("test")
+			d.Set("tags", FlattenTags(sent))
+			return nil
+		},
+	}
+
+	resources := map[string]*schema.Resource{"harness_platform_service": r}
+	ApplyDefaultTags(resources, StateTypes(resources))
+
+	session := &internal.Session{DefaultTags: map[string]string{"team": "platform"}}
+	config := map[string]interface{}{"yaml": "service:\n  identifier: test\n  tags:\n    owner: me\n    team: cd\n"}
+
+	// Tags of the configured YAML are part of `tags_all` and sent with the other tags.
+	diff, err := testDiff(t, r, config, session)
+	require.NoError(t, err)
+	require.Equal(t, "2", diff.Attributes["tags_all.#"].New)
+
+	state, diags := r.Apply(context.Background(), nil, diff, session)
+	require.Nil(t, diags)
+	require.Equal(t, map[string]string{"team": "cd", "owner": "me"}, sent)
+	require.Equal(t, "0", state.Attributes["tags.#"])
+	require.Equal(t, "2", state.Attributes["tags_all.#"])
+
+	// The YAML that Harness generates for resources that don't configure it doesn't add tags.
+	diff, err = testDiff(t, r, map[string]interface{}{}, session)
+	require.NoError(t, err)
+	require.Equal(t, "1", diff.Attributes["tags_all.#"].New)
+
+	_, err = testDiff(t, r, map[string]interface{}{"yaml": "service: ["}, session)
+	require.ErrorContains(t, err, "yaml: invalid YAML")
+}
+
+func TestApplyDefaultTagsStateType(t *testing.T) {
+	r := &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"identifier": {Type: schema.TypeString, Required: true},
+			"tags":       GetTagsSchema(SchemaFlagTypes.Optional),
+		},
+		CreateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics { return nil },
+		ReadContext:   func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics { return nil },
+		UpdateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics { return nil },
+	}
+	resources := map[string]*schema.Resource{"harness_platform_test": r}
+	expected := r.CoreConfigSchema().ImpliedType()
+
+	// The type of version 0 is the one of the resource before it was wrapped.
+	stateTypes := StateTypes(resources)
+	ApplyScopeDefaults(resources)
+	ApplyDefaultTags(resources, stateTypes)
+	ApplyAdoptExisting(resources)
+	ApplyTimeouts(resources)
+
+	require.Contains(t, r.Schema, "adopt_existing")
+	require.NotNil(t, r.Timeouts)
+	require.True(t, expected.Equals(r.StateUpgraders[0].Type), r.StateUpgraders[0].Type.GoString())
+}
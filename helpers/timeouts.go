@@ -0,0 +1,97 @@
+package helpers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/terraform-provider-harness/internal"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	DefaultCreateTimeout = 10 * time.Minute
+	DefaultReadTimeout   = 5 * time.Minute
+	DefaultUpdateTimeout = 10 * time.Minute
+	DefaultDeleteTimeout = 10 * time.Minute
+)
+
+// ApplyTimeouts adds a `timeouts` block to the given resources, unless they declare their own, and
+// turns errors caused by a timeout into a diagnostic naming the operation that timed out.
+//
+// The SDK already runs each operation with a context that has the timeout as its deadline. Next Gen
+// API calls take that context directly, First Gen ones get it through Session.WithContext.
+func ApplyTimeouts(resources map[string]*schema.Resource) {
+	for name, r := range resources {
+		if r.Timeouts == nil {
+			r.Timeouts = getDefaultTimeouts(r)
+		}
+
+		r.CreateContext = withTimeout(r.CreateContext, name, "create", schema.TimeoutCreate)
+		r.ReadContext = withTimeout(r.ReadContext, name, "read", schema.TimeoutRead)
+		r.UpdateContext = withTimeout(r.UpdateContext, name, "update", schema.TimeoutUpdate)
+		r.DeleteContext = withTimeout(r.DeleteContext, name, "delete", schema.TimeoutDelete)
+	}
+}
+
+// getDefaultTimeouts returns the default timeouts for the operations the resource implements.
+func getDefaultTimeouts(r *schema.Resource) *schema.ResourceTimeout {
+	timeouts := &schema.ResourceTimeout{}
+	set := func(t time.Duration) *time.Duration {
+		return &t
+	}
+
+	if r.CreateContext != nil {
+		timeouts.Create = set(DefaultCreateTimeout)
+	}
+	if r.ReadContext != nil {
+		timeouts.Read = set(DefaultReadTimeout)
+	}
+	if r.UpdateContext != nil {
+		timeouts.Update = set(DefaultUpdateTimeout)
+	}
+	if r.DeleteContext != nil {
+		timeouts.Delete = set(DefaultDeleteTimeout)
+	}
+
+	return timeouts
+}
+
+func withTimeout(f func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics, resourceType string, operation string, key string) func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics {
+	if f == nil {
+		return nil
+	}
+
+	return func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		if session, ok := meta.(*internal.Session); ok && session != nil {
+			meta = session.WithContext(ctx)
+		}
+
+		diags := f(ctx, d, meta)
+		if !diags.HasError() || !errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return diags
+		}
+
+		target := resourceType
+		if d.Id() != "" {
+			target = fmt.Sprintf("%s %s", resourceType, d.Id())
+		}
+
+		var causes []string
+		for _, e := range diags {
+			if e.Severity == diag.Error {
+				causes = append(causes, e.Summary)
+			}
+		}
+
+		return diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("Timed out after %s waiting to %s %s", d.Timeout(key), operation, target),
+			Detail: fmt.Sprintf("The %s operation didn't complete in time. The timeout can be increased with `timeouts.%s`.\n\n%s",
+				operation, operation, strings.Join(causes, "\n")),
+		}}
+	}
+}
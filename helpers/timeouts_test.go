@@ -0,0 +1,55 @@
+package helpers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/harness/terraform-provider-harness/internal"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyTimeouts(t *testing.T) {
+	session := &internal.Session{AccountId: "test"}
+	wait := func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		require.NotSame(t, session, meta)
+		require.Equal(t, "test", meta.(*internal.Session).AccountId)
+
+		<-ctx.Done()
+		return diag.FromErr(ctx.Err())
+	}
+	fail := func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		return diag.Errorf("invalid request")
+	}
+
+	timeout := 50 * time.Millisecond
+	custom := &schema.ResourceTimeout{Create: &timeout}
+	resources := map[string]*schema.Resource{
+		"harness_platform_project": {Schema: map[string]*schema.Schema{}, CreateContext: wait, ReadContext: fail, DeleteContext: wait},
+		"harness_application":      {Schema: map[string]*schema.Schema{}, CreateContext: wait, Timeouts: custom},
+	}
+	ApplyTimeouts(resources)
+
+	r := resources["harness_platform_project"]
+	require.Equal(t, DefaultCreateTimeout, *r.Timeouts.Create)
+	require.Equal(t, DefaultReadTimeout, *r.Timeouts.Read)
+	require.Nil(t, r.Timeouts.Update)
+	require.Equal(t, DefaultDeleteTimeout, *r.Timeouts.Delete)
+	require.Same(t, custom, resources["harness_application"].Timeouts)
+
+	d := r.Data(&terraform.InstanceState{ID: "test"})
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	diags := r.DeleteContext(ctx, d, session)
+	require.Len(t, diags, 1)
+	require.Equal(t, "Timed out after 10m0s waiting to delete harness_platform_project test", diags[0].Summary)
+	require.Contains(t, diags[0].Detail, "`timeouts.delete`")
+	require.Contains(t, diags[0].Detail, "context deadline exceeded")
+
+	// Errors that aren't caused by a timeout are returned unchanged.
+	diags = r.ReadContext(context.Background(), d, session)
+	require.Equal(t, "invalid request", diags[0].Summary)
+}
@@ -0,0 +1,201 @@
+package helpers
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// identifierPattern matches the identifiers accepted by Harness.
+var identifierPattern = regexp.MustCompile(`^[a-zA-Z_][0-9a-zA-Z_$]{0,127}$`)
+
+// reservedIdentifiers are the words Harness doesn't accept as identifiers because they are keywords
+// of its expression language.
+var reservedIdentifiers = map[string]bool{
+	"or": true, "and": true, "eq": true, "ne": true, "lt": true, "gt": true, "le": true, "ge": true,
+	"div": true, "mod": true, "not": true, "null": true, "true": true, "false": true, "new": true,
+	"var": true, "return": true,
+}
+
+// Reference prefixes for entities in the account and the organization of the referencing resource.
+// References without a prefix are to entities in the same scope as the resource.
+const (
+	accountReferencePrefix = "account."
+	orgReferencePrefix     = "org."
+)
+
+// identifierAttributes are the attributes holding the identifier of another entity in the same scope.
+var identifierAttributes = map[string]bool{
+	"org_id":      true,
+	"project_id":  true,
+	"pipeline_id": true,
+	"target_id":   true,
+}
+
+// referenceAttributes are the attributes holding a reference to another entity, in addition to the
+// ones ending in `_ref`.
+var referenceAttributes = map[string]bool{
+	"secret_manager_identifier": true,
+	"env_id":                    true,
+	"service_id":                true,
+}
+
+func checkIdentifier(value string) error {
+	if reservedIdentifiers[value] {
+		return fmt.Errorf("%q is a reserved word and can't be used as an identifier", value)
+	}
+	if !identifierPattern.MatchString(value) {
+		return fmt.Errorf("%q is not a valid identifier. Identifiers must start with a letter or `_`, "+
+			"contain only letters, digits, `_` and `$`, and be at most 128 characters long", value)
+	}
+	return nil
+}
+
+func validationDiagnostics(err error, path cty.Path) diag.Diagnostics {
+	if err == nil {
+		return nil
+	}
+	return diag.Diagnostics{{Severity: diag.Error, Summary: err.Error(), AttributePath: path}}
+}
+
+// ValidateIdentifier validates a Harness identifier.
+func ValidateIdentifier(i interface{}, path cty.Path) diag.Diagnostics {
+	return validationDiagnostics(checkIdentifier(i.(string)), path)
+}
+
+// ValidateReference validates a reference to another entity: an identifier that is optionally
+// prefixed with `account.` or `org.`. Expressions such as `<+input>` aren't validated.
+func ValidateReference(i interface{}, path cty.Path) diag.Diagnostics {
+	value := i.(string)
+	if value == "" || strings.HasPrefix(value, "<+") {
+		return nil
+	}
+
+	identifier := value
+	for _, prefix := range []string{accountReferencePrefix, orgReferencePrefix} {
+		if strings.HasPrefix(value, prefix) {
+			identifier = strings.TrimPrefix(value, prefix)
+			break
+		}
+	}
+	if err := checkIdentifier(identifier); err != nil {
+		return validationDiagnostics(fmt.Errorf("%q is not a valid reference: %s. References are identifiers "+
+			"optionally prefixed with `account.` or `org.`", value, err), path)
+	}
+	return nil
+}
+
+// ValidateDelegateSelector validates a delegate selector.
+func ValidateDelegateSelector(i interface{}, path cty.Path) diag.Diagnostics {
+	value := i.(string)
+	if value == "" || strings.TrimSpace(value) != value {
+		return validationDiagnostics(fmt.Errorf("%q is not a valid delegate selector. Delegate selectors "+
+			"must not be empty or start or end with whitespace", value), path)
+	}
+	return nil
+}
+
+// ApplyIdentifierValidation validates the identifier and reference attributes of the given Next Gen
+// resources during plan. References are also checked against the scope of the resource, e.g. an
+// account level resource can't reference an organization level entity.
+func ApplyIdentifierValidation(resources map[string]*schema.Resource) {
+	for _, r := range resources {
+		var references []string
+		addIdentifierValidation(r.Schema, "", &references)
+
+		if len(references) == 0 {
+			continue
+		}
+		sort.Strings(references)
+
+		if r.CustomizeDiff != nil {
+			r.CustomizeDiff = customdiff.Sequence(r.CustomizeDiff, referenceScopeCustomizeDiff(references))
+		} else {
+			r.CustomizeDiff = referenceScopeCustomizeDiff(references)
+		}
+	}
+}
+
+// addIdentifierValidation adds the validation of the identifier-like attributes in a schema and
+// collects the paths of the reference attributes, e.g. `credentials.http.password_ref`.
+func addIdentifierValidation(s map[string]*schema.Schema, prefix string, references *[]string) {
+	for k, v := range s {
+		if elem, ok := v.Elem.(*schema.Resource); ok {
+			addIdentifierValidation(elem.Schema, prefix+k+".", references)
+			continue
+		}
+
+		if v.Computed && !v.Optional {
+			continue
+		}
+
+		switch {
+		case k == "delegate_selectors":
+			if elem, ok := v.Elem.(*schema.Schema); ok && elem.Type == schema.TypeString && !hasValidation(elem) {
+				elem.ValidateDiagFunc = ValidateDelegateSelector
+			}
+		case v.Type != schema.TypeString || hasValidation(v):
+		case k == "identifier" && v.Required, identifierAttributes[k]:
+			v.ValidateDiagFunc = ValidateIdentifier
+		case strings.HasSuffix(k, "_ref"), referenceAttributes[k]:
+			v.ValidateDiagFunc = ValidateReference
+			*references = append(*references, prefix+k)
+		}
+	}
+}
+
+func hasValidation(s *schema.Schema) bool {
+	return s.ValidateFunc != nil || s.ValidateDiagFunc != nil
+}
+
+// referenceScopeCustomizeDiff fails when an account level resource references an organization level
+// entity, which only resources in an organization can do.
+func referenceScopeCustomizeDiff(references []string) schema.CustomizeDiffFunc {
+	return func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+		if _, ok := d.GetOk("org_id"); ok || !d.NewValueKnown("org_id") {
+			return nil
+		}
+
+		for _, path := range references {
+			for _, value := range referenceValues(d.Get(strings.SplitN(path, ".", 2)[0]), strings.Split(path, ".")[1:]) {
+				if strings.HasPrefix(value, orgReferencePrefix) {
+					return fmt.Errorf("%s: %q references an organization level entity but the resource is at account level. "+
+						"Use an `account.` reference or set `org_id`", path, value)
+				}
+			}
+		}
+
+		return nil
+	}
+}
+
+// referenceValues returns the values of a nested attribute, following the given path through lists
+// and sets of blocks.
+func referenceValues(v interface{}, path []string) []string {
+	switch v := v.(type) {
+	case string:
+		if len(path) == 0 {
+			return []string{v}
+		}
+	case []interface{}:
+		var values []string
+		for _, e := range v {
+			values = append(values, referenceValues(e, path)...)
+		}
+		return values
+	case *schema.Set:
+		return referenceValues(v.List(), path)
+	case map[string]interface{}:
+		if len(path) > 0 {
+			return referenceValues(v[path[0]], path[1:])
+		}
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+package helpers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateIdentifier(t *testing.T) {
+	for _, value := range []string{"test", "_test", "Test_1", "test$1"} {
+		require.False(t, ValidateIdentifier(value, cty.GetAttrPath("identifier")).HasError(), value)
+	}
+
+	for _, value := range []string{"", "1test", "test-1", "test.1", "my test", "null", "true", string(make([]byte, 129))} {
+		diags := ValidateIdentifier(value, cty.GetAttrPath("identifier"))
+		require.True(t, diags.HasError(), value)
+		require.Equal(t, cty.GetAttrPath("identifier"), diags[0].AttributePath)
+	}
+}
+
+func TestValidateReference(t *testing.T) {
+	for _, value := range []string{"", "secret", "account.secret", "org.secret", "<+input>", "<+secrets.getValue(\"test\")>"} {
+		require.False(t, ValidateReference(value, cty.GetAttrPath("password_ref")).HasError(), value)
+	}
+
+	for _, value := range []string{"project.secret", "account.", "account.org.secret", "secret-1", "org.true"} {
+		require.True(t, ValidateReference(value, cty.GetAttrPath("password_ref")).HasError(), value)
+	}
+}
+
+func TestValidateDelegateSelector(t *testing.T) {
+	require.False(t, ValidateDelegateSelector("k8s delegate", nil).HasError())
+	require.True(t, ValidateDelegateSelector("", nil).HasError())
+	require.True(t, ValidateDelegateSelector(" k8s", nil).HasError())
+}
+
+func TestApplyIdentifierValidation(t *testing.T) {
+	r := &schema.Resource{Schema: map[string]*schema.Schema{}}
+	SetMultiLevelResourceSchema(r.Schema)
+	r.Schema["delegate_selectors"] = &schema.Schema{Type: schema.TypeSet, Optional: true, Elem: &schema.Schema{Type: schema.TypeString}}
+	r.Schema["secret_manager_identifier"] = &schema.Schema{Type: schema.TypeString, Optional: true}
+	r.Schema["status_ref"] = &schema.Schema{Type: schema.TypeString, Computed: true}
+	r.Schema["credentials"] = &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		Elem: &schema.Resource{Schema: map[string]*schema.Schema{
+			"password_ref": {Type: schema.TypeString, Optional: true},
+		}},
+	}
+	ApplyIdentifierValidation(map[string]*schema.Resource{"harness_platform_test": r})
+
+	require.NotNil(t, r.Schema["identifier"].ValidateDiagFunc)
+	require.NotNil(t, r.Schema["org_id"].ValidateDiagFunc)
+	require.NotNil(t, r.Schema["secret_manager_identifier"].ValidateDiagFunc)
+	require.NotNil(t, r.Schema["delegate_selectors"].Elem.(*schema.Schema).ValidateDiagFunc)
+	require.NotNil(t, r.Schema["credentials"].Elem.(*schema.Resource).Schema["password_ref"].ValidateDiagFunc)
+	require.Nil(t, r.Schema["status_ref"].ValidateDiagFunc)
+	require.NoError(t, r.InternalValidate(nil, true))
+
+	// Organization references are only allowed when the resource is in an organization.
+	config := map[string]interface{}{"identifier": "test", "name": "test"}
+	config["credentials"] = []interface{}{map[string]interface{}{"password_ref": "org.secret"}}
+	_, err := r.Diff(context.Background(), nil, terraform.NewResourceConfigRaw(config), nil)
+	require.EqualError(t, err, "credentials.password_ref: \"org.secret\" references an organization level entity but the resource is at account level. Use an `account.` reference or set `org_id`")
+
+	config["org_id"] = "org"
+	_, err = r.Diff(context.Background(), nil, terraform.NewResourceConfigRaw(config), nil)
+	require.NoError(t, err)
+
+	delete(config, "org_id")
+	config["credentials"] = []interface{}{map[string]interface{}{"password_ref": "account.secret"}}
+	config["secret_manager_identifier"] = "harnessSecretManager"
+	_, err = r.Diff(context.Background(), nil, terraform.NewResourceConfigRaw(config), nil)
+	require.NoError(t, err)
+}
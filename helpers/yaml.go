@@ -0,0 +1,234 @@
+package helpers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"gopkg.in/yaml.v3"
+)
+
+// YamlDiffSuppressFunc suppresses the diff of a YAML attribute when the configured and stored
+// documents are semantically equal. Harness re-serializes the YAML it stores, changing the key
+// order, quoting and indentation, and adds fields with their default values.
+func YamlDiffSuppressFunc(k, old, new string, d *schema.ResourceData) bool {
+	return YamlEqual(old, new)
+}
+
+// YamlEqual reports whether two YAML documents are semantically equal. Mappings are compared
+// regardless of key order, scalars by type and value regardless of quoting or style, and a key that
+// is only present in one of the documents is ignored when it is a server default, see
+// yamlServerDefaults.
+func YamlEqual(a, b string) bool {
+	if a == b {
+		return true
+	}
+	if strings.TrimSpace(a) == "" || strings.TrimSpace(b) == "" {
+		return false
+	}
+
+	var nodeA, nodeB yaml.Node
+	if yaml.Unmarshal([]byte(a), &nodeA) != nil || yaml.Unmarshal([]byte(b), &nodeB) != nil {
+		return false
+	}
+
+	return yamlNodesEqual(&nodeA, &nodeB, nil)
+}
+
+// yamlServerDefaults are the fields Harness adds to the YAML it stores, by path and with their
+// default value. `*` matches any element of a sequence. Only these fields can be left out, and only
+// with their default value.
+var yamlServerDefaults = map[string]yaml.Node{
+	"pipeline.tags":                                         yamlEmptyMapping,
+	"pipeline.description":                                  yamlEmptyString,
+	"pipeline.allowStageExecutions":                         yamlFalse,
+	"pipeline.stages.*.stage.tags":                          yamlEmptyMapping,
+	"pipeline.stages.*.stage.description":                   yamlEmptyString,
+	"pipeline.stages.*.stage.variables":                     yamlEmptySequence,
+	"trigger.enabled":                                       {Kind: yaml.ScalarNode, Tag: "!!bool", Value: "true"},
+	"trigger.tags":                                          yamlEmptyMapping,
+	"trigger.description":                                   yamlEmptyString,
+	"inputSet.tags":                                         yamlEmptyMapping,
+	"inputSet.description":                                  yamlEmptyString,
+	"service.tags":                                          yamlEmptyMapping,
+	"service.description":                                   yamlEmptyString,
+	"environment.tags":                                      yamlEmptyMapping,
+	"environment.description":                               yamlEmptyString,
+	"infrastructureDefinition.tags":                         yamlEmptyMapping,
+	"infrastructureDefinition.description":                  yamlEmptyString,
+	"infrastructureDefinition.allowSimultaneousDeployments": yamlFalse,
+	"environmentGroup.tags":                                 yamlEmptyMapping,
+	"environmentGroup.description":                          yamlEmptyString,
+}
+
+var (
+	yamlEmptyMapping  = yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+	yamlEmptySequence = yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+	yamlEmptyString   = yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str"}
+	yamlFalse         = yaml.Node{Kind: yaml.ScalarNode, Tag: "!!bool", Value: "false"}
+)
+
+// yamlNodesEqual compares a and b, which are found at path in their documents.
+func yamlNodesEqual(a, b *yaml.Node, path []string) bool {
+	a, b = resolveYamlNode(a), resolveYamlNode(b)
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	if isYamlNull(a) || isYamlNull(b) {
+		return isYamlNull(a) && isYamlNull(b)
+	}
+
+	if a.Kind != b.Kind {
+		return false
+	}
+
+	switch a.Kind {
+	case yaml.ScalarNode:
+		return a.ShortTag() == b.ShortTag() && a.Value == b.Value
+	case yaml.SequenceNode:
+		if len(a.Content) != len(b.Content) {
+			return false
+		}
+		for i := range a.Content {
+			if !yamlNodesEqual(a.Content[i], b.Content[i], yamlPath(path, "*")) {
+				return false
+			}
+		}
+		return true
+	case yaml.MappingNode:
+		mapA, mapB := yamlMapping(a), yamlMapping(b)
+		for k, v := range mapA {
+			if other, ok := mapB[k]; ok {
+				if !yamlNodesEqual(v, other, yamlPath(path, k)) {
+					return false
+				}
+			} else if !isYamlDefault(yamlPath(path, k), v) {
+				return false
+			}
+		}
+		for k, v := range mapB {
+			if _, ok := mapA[k]; !ok && !isYamlDefault(yamlPath(path, k), v) {
+				return false
+			}
+		}
+		return true
+	}
+
+	return false
+}
+
+// resolveYamlNode unwraps documents and aliases.
+func resolveYamlNode(n *yaml.Node) *yaml.Node {
+	for n != nil {
+		switch {
+		case n.Kind == yaml.DocumentNode && len(n.Content) > 0:
+			n = n.Content[0]
+		case n.Kind == yaml.DocumentNode:
+			return nil
+		case n.Kind == yaml.AliasNode:
+			n = n.Alias
+		default:
+			return n
+		}
+	}
+	return nil
+}
+
+func yamlMapping(n *yaml.Node) map[string]*yaml.Node {
+	m := make(map[string]*yaml.Node, len(n.Content)/2)
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		m[n.Content[i].Value] = n.Content[i+1]
+	}
+	return m
+}
+
+func isYamlNull(n *yaml.Node) bool {
+	return n.Kind == yaml.ScalarNode && n.Tag == "!!null"
+}
+
+func yamlPath(path []string, key string) []string {
+	return append(append(make([]string, 0, len(path)+1), path...), key)
+}
+
+// isYamlDefault reports whether the field at path can be left out without changing the document,
+// i.e. it is a server default and n is its default value.
+func isYamlDefault(path []string, n *yaml.Node) bool {
+	def, ok := yamlServerDefaults[strings.Join(path, ".")]
+	if n = resolveYamlNode(n); !ok || n == nil || n.Kind != def.Kind || n.ShortTag() != def.Tag {
+		return false
+	}
+
+	if n.Kind == yaml.ScalarNode {
+		return n.Value == def.Value
+	}
+	return len(n.Content) == 0
+}
+
+// YamlConsistencyCustomizeDiff checks at plan time that the `yaml` attribute is a document with
+// the single root key rootKey, e.g. `pipeline`, and that it agrees with the resource attributes
+// that are sent alongside it. fields maps each attribute to the path of the matching YAML field
+// below the root key, e.g. `org_id` to `orgIdentifier` or `pipeline_id` to `pipeline.identifier`.
+// Fields the YAML leaves out and values that aren't known until apply are not checked.
+func YamlConsistencyCustomizeDiff(rootKey string, fields map[string]string) schema.CustomizeDiffFunc {
+	return func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+		if !d.NewValueKnown("yaml") {
+			return nil
+		}
+
+		doc := map[string]interface{}{}
+		if err := yaml.Unmarshal([]byte(d.Get("yaml").(string)), &doc); err != nil {
+			return fmt.Errorf("yaml: invalid YAML: %s", err)
+		}
+
+		var keys []string
+		for k := range doc {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		if len(keys) != 1 || keys[0] != rootKey {
+			return fmt.Errorf("yaml: expected a single `%s` root key, got `%s`", rootKey, strings.Join(keys, "`, `"))
+		}
+
+		attrs := make([]string, 0, len(fields))
+		for attr := range fields {
+			attrs = append(attrs, attr)
+		}
+		sort.Strings(attrs)
+
+		for _, attr := range attrs {
+			path := fields[attr]
+			value, ok := yamlLookup(doc[rootKey], strings.Split(path, "."))
+			if !ok || !d.NewValueKnown(attr) {
+				continue
+			}
+
+			if expected := d.Get(attr).(string); fmt.Sprint(value) != expected {
+				return fmt.Errorf("yaml: `%s.%s` is %q but `%s` is %q, they must be the same", rootKey, path, fmt.Sprint(value), attr, expected)
+			}
+		}
+
+		return nil
+	}
+}
+
+// yamlLookup returns the scalar at path in a decoded YAML document.
+func yamlLookup(v interface{}, path []string) (interface{}, bool) {
+	for _, key := range path {
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		if v, ok = m[key]; !ok {
+			return nil, false
+		}
+	}
+
+	switch v.(type) {
+	case nil, map[string]interface{}, []interface{}:
+		return nil, false
+	}
+	return v, true
+}
@@ -0,0 +1,198 @@
+package helpers
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/require"
+)
+
+// The configured pipeline and the pipeline as it is returned by the API after saving it.
+const testPipelineConfig = `
+pipeline:
+    name: test
+    identifier: test
+    projectIdentifier: project
+    orgIdentifier: org
+    stages:
+        - stage:
+            name: build
+            identifier: build
+            type: CI
+            spec:
+                cloneCodebase: false
+                execution:
+                    steps:
+                        - step:
+                            type: Run
+                            name: echo
+                            identifier: echo
+                            spec:
+                                shell: Bash
+                                command: |-
+                                    echo "hello"
+                                    echo 'world'
+                            timeout: "10m"
+`
+
+const testPipelineServer = `pipeline:
+  name: "test"
+  identifier: "test"
+  projectIdentifier: "project"
+  orgIdentifier: "org"
+  tags: {}
+  allowStageExecutions: false
+  stages:
+  - stage:
+      name: "build"
+      identifier: "build"
+      description: ""
+      type: "CI"
+      spec:
+        cloneCodebase: false
+        execution:
+          steps:
+          - step:
+              type: "Run"
+              name: "echo"
+              identifier: "echo"
+              spec:
+                shell: "Bash"
+                command: "echo \"hello\"\necho 'world'"
+              timeout: "10m"
+      variables: []
+`
+
+const testTriggerConfig = `
+trigger:
+  name: test
+  identifier: test
+  orgIdentifier: org
+  projectIdentifier: project
+  pipelineIdentifier: pipeline
+  source:
+    type: Scheduled
+    spec:
+      type: Cron
+      spec:
+        expression: 0 2 * * *
+  inputYaml: |
+    pipeline: {}
+`
+
+const testTriggerServer = `trigger:
+  name: "test"
+  identifier: "test"
+  enabled: true
+  description: ""
+  tags: {}
+  orgIdentifier: "org"
+  projectIdentifier: "project"
+  pipelineIdentifier: "pipeline"
+  source:
+    type: "Scheduled"
+    spec:
+      type: "Cron"
+      spec:
+        expression: "0 2 * * *"
+  inputYaml: "pipeline: {}\n"
+`
+
+func TestYamlEqual(t *testing.T) {
+	require.True(t, YamlEqual(testPipelineConfig, testPipelineServer))
+	require.True(t, YamlEqual(testPipelineServer, testPipelineConfig))
+	require.True(t, YamlEqual(testTriggerConfig, testTriggerServer))
+
+	// Comments, anchors and null values.
+	require.True(t, YamlEqual("a: &x {b: 1}\nc: *x\n", "# comment\nc:\n  b: 1\na:\n  b: 1\n"))
+	require.True(t, YamlEqual("a: null", "a: ~"))
+
+	require.False(t, YamlEqual(testPipelineConfig, `pipeline: {name: test}`))
+	require.False(t, YamlEqual("a: 1", "a: 2"))
+	require.False(t, YamlEqual("a: [1, 2]", "a: [2, 1]"))
+	require.False(t, YamlEqual("a: 1", "a: 1\nb: 2"))
+	require.False(t, YamlEqual("a: 1", "a: {b: 1}"))
+
+	// Only server defaults can be left out, and only with their default value.
+	require.True(t, YamlEqual("trigger: {a: 1}", "trigger: {enabled: true, a: 1}"))
+	require.False(t, YamlEqual("trigger: {a: 1}", "trigger: {enabled: false, a: 1}"))
+	require.False(t, YamlEqual("trigger: {enabled: false, a: 1}", "trigger: {a: 1}"))
+	require.False(t, YamlEqual("trigger: {a: 1}", "trigger: {enabled: \"true\", a: 1}"))
+	require.False(t, YamlEqual("trigger: {a: 1}", "trigger: {enabled: null, a: 1}"))
+	require.False(t, YamlEqual("trigger: {a: 1}", "trigger: {description: x, a: 1}"))
+
+	// Other fields are compared even when they are empty, false or null.
+	require.False(t, YamlEqual("a: 1", "enabled: true\na: 1"))
+	require.False(t, YamlEqual("pipeline: {a: 1}", "pipeline: {a: 1, failureStrategies: []}"))
+	require.False(t, YamlEqual("pipeline: {a: 1}", "pipeline: {a: 1, b: false}"))
+	require.False(t, YamlEqual("pipeline: {a: 1}", "pipeline: {a: 1, b: \"\"}"))
+	require.False(t, YamlEqual("pipeline: {a: 1}", "pipeline: {a: 1, b: ~}"))
+	require.False(t, YamlEqual("stage: {a: 1}", "stage: {a: 1, variables: []}"))
+
+	// Scalars of different types are different, even when their values are.
+	require.False(t, YamlEqual("a: true", "a: \"true\""))
+	require.False(t, YamlEqual("a: 1", "a: \"1\""))
+	require.True(t, YamlEqual("a: '1'", "a: \"1\""))
+
+	// Invalid or empty documents are only equal when they are identical.
+	require.False(t, YamlEqual("a: [", "a: [ "))
+	require.True(t, YamlEqual("a: [", "a: ["))
+	require.False(t, YamlEqual("", "a: 1"))
+}
+
+func TestYamlDiffSuppressFunc(t *testing.T) {
+	require.True(t, YamlDiffSuppressFunc("yaml", testTriggerServer, testTriggerConfig, nil))
+	require.False(t, YamlDiffSuppressFunc("yaml", testTriggerServer, "trigger: {}", nil))
+
+	// Disabling a trigger whose YAML left out `enabled` is a change.
+	disabled := strings.Replace(testTriggerConfig, "  name: test\n", "  name: test\n  enabled: false\n", 1)
+	require.False(t, YamlDiffSuppressFunc("yaml", testTriggerServer, disabled, nil))
+}
+
+func TestYamlConsistencyCustomizeDiff(t *testing.T) {
+	r := &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"pipeline_id": {Type: schema.TypeString, Required: true},
+			"yaml":        {Type: schema.TypeString, Required: true},
+		},
+		CustomizeDiff: YamlConsistencyCustomizeDiff("inputSet", map[string]string{
+			"identifier":  "identifier",
+			"name":        "name",
+			"org_id":      "orgIdentifier",
+			"project_id":  "projectIdentifier",
+			"pipeline_id": "pipeline.identifier",
+		}),
+	}
+	SetProjectLevelResourceSchema(r.Schema)
+
+	config := func(yaml string) map[string]interface{} {
+		return map[string]interface{}{"identifier": "test", "name": "test", "org_id": "org", "project_id": "project", "pipeline_id": "pipeline", "yaml": yaml}
+	}
+
+	_, err := testDiff(t, r, config(`
+inputSet:
+  identifier: test
+  orgIdentifier: org
+  projectIdentifier: project
+  pipeline:
+    identifier: pipeline
+    variables: []
+`), nil)
+	require.NoError(t, err)
+
+	_, err = testDiff(t, r, config("inputSet:\n  identifier: other\n"), nil)
+	require.EqualError(t, err, "yaml: `inputSet.identifier` is \"other\" but `identifier` is \"test\", they must be the same")
+
+	_, err = testDiff(t, r, config("inputSet:\n  orgIdentifier: org\n  pipeline:\n    identifier: other\n"), nil)
+	require.EqualError(t, err, "yaml: `inputSet.pipeline.identifier` is \"other\" but `pipeline_id` is \"pipeline\", they must be the same")
+
+	_, err = testDiff(t, r, config("pipeline:\n  identifier: test\n"), nil)
+	require.EqualError(t, err, "yaml: expected a single `inputSet` root key, got `pipeline`")
+
+	_, err = testDiff(t, r, config("inputSet:\n  identifier: test\ntrigger: {}\n"), nil)
+	require.EqualError(t, err, "yaml: expected a single `inputSet` root key, got `inputSet`, `trigger`")
+
+	_, err = testDiff(t, r, config("inputSet:\n  identifier: [test\n"), nil)
+	require.ErrorContains(t, err, "yaml: invalid YAML")
+}
@@ -66,7 +66,7 @@ func TestAccGetApplication(resourceName string, state *terraform.State) (*graphq
 	c := TestAccGetApiClientFromProvider()
 	id := r.Primary.ID
 
-	return c.CDClient.ApplicationClient.GetApplicationById(id)
+	return c.GetCDClient().ApplicationClient.GetApplicationById(id)
 }
 
 func PipelineResourceImportStateIdFunc(resourceName string) resource.ImportStateIdFunc {
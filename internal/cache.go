@@ -0,0 +1,181 @@
+package internal
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// versionSegment matches the api version segments of a path, e.g. `v2`.
+var versionSegment = regexp.MustCompile(`^v\d+$`)
+
+// ReadCache is an in-memory cache of GET responses that lasts for the lifetime of the provider
+// process. Only reads made by data sources are cached and coalesced, since resources need fresh reads
+// to detect drift and to see their own writes: identical data source GETs in flight at the same time
+// are sent as a single request. Any other request invalidates the cached responses of the collection
+// it changes.
+//
+// The cache is the transport of the Next Gen clients held by the Session rather than a field of it.
+// Every request made through GetPlatformClientWithContext or PLHTTPClient goes through it, keyed by
+// path and scope query, without the resources and data sources having to opt in one by one.
+type ReadCache struct {
+	transport http.RoundTripper
+
+	mu         sync.Mutex
+	generation int
+	entries    map[string]*cachedResponse
+	inflight   map[string]*inflightRequest
+}
+
+type cachedResponse struct {
+	collection string
+	status     string
+	statusCode int
+	header     http.Header
+	body       []byte
+}
+
+type inflightRequest struct {
+	done chan struct{}
+	resp *cachedResponse
+	err  error
+}
+
+func NewReadCache(transport http.RoundTripper) *ReadCache {
+	return &ReadCache{
+		transport: transport,
+		entries:   map[string]*cachedResponse{},
+		inflight:  map[string]*inflightRequest{},
+	}
+}
+
+func (c *ReadCache) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet {
+		resp, err := c.transport.RoundTrip(req)
+		if req.Method != http.MethodHead && req.Method != http.MethodOptions {
+			c.invalidate(req.URL.Path)
+		}
+		return resp, err
+	}
+
+	if !strings.HasPrefix(GetResourceType(req.Context()), "data.") {
+		return c.transport.RoundTrip(req)
+	}
+
+	// The query holds the account, org and project identifiers so the key includes the scope.
+	key := req.URL.String()
+
+	c.mu.Lock()
+	if entry, ok := c.entries[key]; ok {
+		c.mu.Unlock()
+		return entry.response(req), nil
+	}
+	if call, ok := c.inflight[key]; ok {
+		c.mu.Unlock()
+		select {
+		case <-call.done:
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		}
+		if call.err != nil {
+			return nil, call.err
+		}
+		return call.resp.response(req), nil
+	}
+	call := &inflightRequest{done: make(chan struct{})}
+	c.inflight[key] = call
+	generation := c.generation
+	c.mu.Unlock()
+
+	call.resp, call.err = c.roundTrip(req)
+
+	c.mu.Lock()
+	delete(c.inflight, key)
+	// Responses that raced with a write may be stale, so they are only returned to the callers.
+	if call.err == nil && call.resp.statusCode == http.StatusOK && generation == c.generation {
+		c.entries[key] = call.resp
+	}
+	c.mu.Unlock()
+	close(call.done)
+
+	if call.err != nil {
+		return nil, call.err
+	}
+	return call.resp.response(req), nil
+}
+
+func (c *ReadCache) roundTrip(req *http.Request) (*cachedResponse, error) {
+	resp, err := c.transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	return &cachedResponse{
+		collection: collectionPath(req.URL.Path),
+		status:     resp.Status,
+		statusCode: resp.StatusCode,
+		header:     resp.Header,
+		body:       body,
+	}, nil
+}
+
+// invalidate removes the cached responses in the same collection as path, e.g. a write to
+// `/ng/api/connectors` invalidates every cached read under `/ng/api/connectors/`.
+func (c *ReadCache) invalidate(path string) {
+	collection := collectionPath(path)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.generation++
+	for key, entry := range c.entries {
+		if entry.collection == collection {
+			delete(c.entries, key)
+		}
+	}
+}
+
+func (r *cachedResponse) response(req *http.Request) *http.Response {
+	return &http.Response{
+		Status:        r.status,
+		StatusCode:    r.statusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        r.header.Clone(),
+		Body:          ioutil.NopCloser(bytes.NewReader(r.body)),
+		ContentLength: int64(len(r.body)),
+		Request:       req,
+	}
+}
+
+// collectionPath returns the part of path up to and including the collection following the `api`
+// segment, e.g. `/gateway/ng/api/v2/secrets` for `/gateway/ng/api/v2/secrets/my_secret`. Paths
+// without an `api` segment are returned unchanged.
+func collectionPath(path string) string {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	for i, s := range segments {
+		if s != "api" {
+			continue
+		}
+
+		end := i + 1
+		for end < len(segments) && versionSegment.MatchString(segments[end]) {
+			end++
+		}
+		if end < len(segments) {
+			end++
+		}
+		return "/" + strings.Join(segments[:end], "/")
+	}
+	return path
+}
@@ -0,0 +1,145 @@
+package internal
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newCacheTestServer(t *testing.T, delay time.Duration) (*httptest.Server, *int32) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&calls, 1)
+		time.Sleep(delay)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"status":"SUCCESS","data":{"call":` + strconv.Itoa(int(n)) + `}}`))
+	}))
+	t.Cleanup(server.Close)
+	return server, &calls
+}
+
+func doCached(t *testing.T, c *ReadCache, ctx context.Context, method string, url string) string {
+	req, err := http.NewRequestWithContext(ctx, method, url, nil)
+	require.NoError(t, err)
+	resp, err := c.RoundTrip(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return string(body)
+}
+
+func TestReadCache(t *testing.T) {
+	server, calls := newCacheTestServer(t, 0)
+	c := NewReadCache(http.DefaultTransport)
+
+	data := WithResourceType(context.Background(), "data.harness_platform_connector_github")
+	resource := WithResourceType(context.Background(), "harness_platform_connector_github")
+
+	connector := server.URL + "/gateway/ng/api/connectors/github?accountIdentifier=a&orgIdentifier=o"
+	otherScope := server.URL + "/gateway/ng/api/connectors/github?accountIdentifier=a"
+	secret := server.URL + "/gateway/ng/api/v2/secrets/token?accountIdentifier=a"
+
+	first := doCached(t, c, data, http.MethodGet, connector)
+	require.Equal(t, first, doCached(t, c, data, http.MethodGet, connector))
+	require.Equal(t, int32(1), atomic.LoadInt32(calls))
+
+	// Resources and other scopes are not served from the cache.
+	require.NotEqual(t, first, doCached(t, c, resource, http.MethodGet, connector))
+	doCached(t, c, data, http.MethodGet, otherScope)
+	require.Equal(t, int32(3), atomic.LoadInt32(calls))
+
+	doCached(t, c, data, http.MethodGet, secret)
+	require.Equal(t, int32(4), atomic.LoadInt32(calls))
+
+	// A write invalidates its own collection only.
+	doCached(t, c, resource, http.MethodPut, server.URL+"/gateway/ng/api/connectors?accountIdentifier=a")
+	require.Equal(t, int32(5), atomic.LoadInt32(calls))
+
+	doCached(t, c, data, http.MethodGet, secret)
+	require.Equal(t, int32(5), atomic.LoadInt32(calls))
+
+	require.NotEqual(t, first, doCached(t, c, data, http.MethodGet, connector))
+	require.Equal(t, int32(6), atomic.LoadInt32(calls))
+}
+
+func TestReadCacheCoalescesRequests(t *testing.T) {
+	server, calls := newCacheTestServer(t, 100*time.Millisecond)
+	c := NewReadCache(http.DefaultTransport)
+
+	url := server.URL + "/gateway/ng/api/projects/test?accountIdentifier=a&orgIdentifier=o"
+	data := WithResourceType(context.Background(), "data.harness_platform_project")
+
+	read := func(ctx context.Context) []string {
+		var wg sync.WaitGroup
+		bodies := make([]string, 10)
+		for i := range bodies {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				bodies[i] = doCached(t, c, ctx, http.MethodGet, url)
+			}(i)
+		}
+		wg.Wait()
+		return bodies
+	}
+
+	for _, body := range read(data) {
+		require.True(t, strings.Contains(body, `"call":1`))
+	}
+	require.Equal(t, int32(1), atomic.LoadInt32(calls))
+
+	// Reads made by resources are neither cached nor coalesced, so they can't get a response to a
+	// request sent before one of their writes.
+	read(WithResourceType(context.Background(), "harness_platform_project"))
+	require.Equal(t, int32(11), atomic.LoadInt32(calls))
+}
+
+func TestReadCacheWaiterContext(t *testing.T) {
+	server, calls := newCacheTestServer(t, 500*time.Millisecond)
+	c := NewReadCache(http.DefaultTransport)
+
+	url := server.URL + "/gateway/ng/api/projects/test?accountIdentifier=a&orgIdentifier=o"
+	data := WithResourceType(context.Background(), "data.harness_platform_project")
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		doCached(t, c, data, http.MethodGet, url)
+	}()
+
+	// Wait for the first request to be in flight before coalescing onto it.
+	for atomic.LoadInt32(calls) == 0 {
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	ctx, cancel := context.WithTimeout(data, 50*time.Millisecond)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	require.NoError(t, err)
+
+	start := time.Now()
+	_, err = c.RoundTrip(req)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.Less(t, time.Since(start), 400*time.Millisecond)
+
+	<-done
+	require.Equal(t, int32(1), atomic.LoadInt32(calls))
+}
+
+func TestCollectionPath(t *testing.T) {
+	require.Equal(t, "/gateway/ng/api/connectors", collectionPath("/gateway/ng/api/connectors/github"))
+	require.Equal(t, "/gateway/ng/api/connectors", collectionPath("/gateway/ng/api/connectors"))
+	require.Equal(t, "/gateway/ng/api/v2/secrets", collectionPath("/gateway/ng/api/v2/secrets/files/token"))
+	require.Equal(t, "/pipeline/api/pipelines", collectionPath("/pipeline/api/pipelines/v2/test"))
+	require.Equal(t, "/other/path", collectionPath("/other/path"))
+}
@@ -0,0 +1,43 @@
+package internal
+
+import (
+	"context"
+	"net/url"
+)
+
+type contextKey string
+
+const (
+	resourceTypeKey contextKey = "resourceType"
+	queryParamsKey  contextKey = "queryParams"
+)
+
+// WithResourceType returns a context recording the Terraform resource type that makes API calls
+// with it, e.g. `harness_platform_connector_github`.
+func WithResourceType(ctx context.Context, resourceType string) context.Context {
+	return context.WithValue(ctx, resourceTypeKey, resourceType)
+}
+
+// GetResourceType returns the resource type recorded by WithResourceType, if any.
+func GetResourceType(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	resourceType, _ := ctx.Value(resourceTypeKey).(string)
+	return resourceType
+}
+
+// WithQueryParams returns a context whose API calls have the given query parameters added. It is
+// used for parameters the SDK doesn't support yet, e.g. `forceDelete`.
+func WithQueryParams(ctx context.Context, params url.Values) context.Context {
+	return context.WithValue(ctx, queryParamsKey, params)
+}
+
+// GetQueryParams returns the query parameters recorded by WithQueryParams, if any.
+func GetQueryParams(ctx context.Context) url.Values {
+	if ctx == nil {
+		return nil
+	}
+	params, _ := ctx.Value(queryParamsKey).(url.Values)
+	return params
+}
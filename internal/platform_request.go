@@ -0,0 +1,97 @@
+package internal
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/hashicorp/go-retryablehttp"
+)
+
+// ApiError is the error returned by PlatformRequest for responses with an error status. Like the
+// errors of the Next Gen SDK it exposes the response body, so it is handled the same way.
+type ApiError struct {
+	StatusCode int
+	message    string
+	body       []byte
+}
+
+func (e ApiError) Error() string {
+	return e.message
+}
+
+// Body returns the body of the response.
+func (e ApiError) Body() []byte {
+	return e.body
+}
+
+// PlatformRequest sends a request to the Next Gen API and decodes the JSON response into v. It is
+// used for endpoints whose models the SDK can't decode, e.g. connectors of types it doesn't know.
+// path is relative to the endpoint, e.g. `/ng/api/connectors`, and the account identifier is added
+// to query. body, when not nil, is sent as JSON.
+func (s *Session) PlatformRequest(ctx context.Context, method string, path string, query url.Values, body interface{}, v interface{}) (*http.Response, error) {
+	c := s.getPLClient()
+	if c == nil || s.PLHTTPClient == nil {
+		return nil, errors.New("the Next Gen client isn't configured, set `platform_api_key` on the provider")
+	}
+
+	if query == nil {
+		query = url.Values{}
+	}
+	query.Set("accountIdentifier", s.AccountId)
+
+	var reqBody interface{}
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		reqBody = b
+	}
+
+	req, err := retryablehttp.NewRequestWithContext(ctx, method, c.Endpoint+path+"?"+query.Encode(), reqBody)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("x-api-key", c.ApiKey)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := s.PLHTTPClient.Do(req)
+	if err != nil {
+		return resp, err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return resp, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	if resp.StatusCode >= 300 {
+		apiErr := ApiError{StatusCode: resp.StatusCode, message: resp.Status, body: respBody}
+		var failure struct {
+			Message string `json:"message"`
+		}
+		if json.Unmarshal(respBody, &failure) == nil && failure.Message != "" {
+			apiErr.message = failure.Message
+		}
+		return resp, apiErr
+	}
+
+	if v != nil {
+		if err := json.Unmarshal(respBody, v); err != nil {
+			return resp, fmt.Errorf("error decoding the response of %s %s: %s", method, path, err)
+		}
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,57 @@
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/harness/harness-go-sdk/harness/nextgen"
+	"github.com/hashicorp/go-retryablehttp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlatformRequest(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "key", r.Header.Get("x-api-key"))
+		require.Equal(t, "test", r.URL.Query().Get("accountIdentifier"))
+
+		switch r.URL.Path {
+		case "/ng/api/connectors":
+			var body map[string]interface{}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			json.NewEncoder(w).Encode(map[string]interface{}{"data": body})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"code": "RESOURCE_NOT_FOUND_EXCEPTION", "message": "Connector not found"}`))
+		}
+	}))
+	defer server.Close()
+
+	httpClient := retryablehttp.NewClient()
+	httpClient.RetryMax = 0
+	session := &Session{
+		AccountId: "test",
+		NewPLClient: func() *nextgen.APIClient {
+			return nextgen.NewAPIClient(&nextgen.Configuration{AccountId: "test", BasePath: server.URL, ApiKey: "key", HTTPClient: httpClient})
+		},
+		PLHTTPClient: httpClient,
+	}
+
+	var resp struct {
+		Data map[string]string `json:"data"`
+	}
+	_, err := session.PlatformRequest(context.Background(), http.MethodPost, "/ng/api/connectors", url.Values{}, map[string]string{"type": "Jenkins"}, &resp)
+	require.NoError(t, err)
+	require.Equal(t, "Jenkins", resp.Data["type"])
+
+	httpResp, err := session.PlatformRequest(context.Background(), http.MethodGet, "/ng/api/connectors/test", nil, nil, nil)
+	require.EqualError(t, err, "Connector not found")
+	require.Equal(t, http.StatusNotFound, httpResp.StatusCode)
+	require.Contains(t, string(err.(ApiError).Body()), "RESOURCE_NOT_FOUND_EXCEPTION")
+
+	_, err = (&Session{}).PlatformRequest(context.Background(), http.MethodGet, "/ng/api/connectors/test", nil, nil, nil)
+	require.Error(t, err)
+}
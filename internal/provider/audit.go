@@ -0,0 +1,113 @@
+package provider
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/harness/terraform-provider-harness/internal"
+)
+
+// auditEntry is a single line of the audit log.
+type auditEntry struct {
+	Time          string `json:"time"`
+	Method        string `json:"method"`
+	Path          string `json:"path"`
+	Status        int    `json:"status,omitempty"`
+	DurationMs    int64  `json:"duration_ms"`
+	CorrelationId string `json:"correlation_id,omitempty"`
+	ResourceType  string `json:"resource_type,omitempty"`
+	Error         string `json:"error,omitempty"`
+}
+
+// auditTransport writes one JSON line per request to the audit log. Request and response bodies
+// aren't logged, as they hold secrets under too many different field names to redact reliably. It sits below the rate limiter
+// so every attempt made by the retry client is recorded with the time spent on the wire.
+type auditTransport struct {
+	mu        sync.Mutex
+	w         io.Writer
+	transport http.RoundTripper
+}
+
+// newAuditTransport appends the audit log of transport to the file at path. An empty path returns
+// transport unchanged.
+func newAuditTransport(path string, transport http.RoundTripper) (http.RoundTripper, error) {
+	if path == "" {
+		return transport, nil
+	}
+
+	path, err := expandHomeDir(path)
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("error opening audit log: %s", err)
+	}
+
+	return &auditTransport{w: f, transport: transport}, nil
+}
+
+func (t *auditTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	entry := &auditEntry{
+		Time:         time.Now().UTC().Format(time.RFC3339Nano),
+		Method:       req.Method,
+		Path:         req.URL.Path,
+		ResourceType: internal.GetResourceType(req.Context()),
+	}
+
+	start := time.Now()
+	resp, err := t.transport.RoundTrip(req)
+	entry.DurationMs = time.Since(start).Milliseconds()
+
+	if err != nil {
+		entry.Error = err.Error()
+	} else {
+		entry.Status = resp.StatusCode
+		entry.CorrelationId = readCorrelationId(resp)
+	}
+
+	t.write(entry)
+
+	return resp, err
+}
+
+func (t *auditTransport) write(entry *auditEntry) {
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.w.Write(append(line, '\n'))
+}
+
+// readCorrelationId returns the `correlationId` of a JSON response, restoring the body so it can
+// still be read by the client.
+func readCorrelationId(resp *http.Response) string {
+	if resp.Body == nil || !strings.Contains(resp.Header.Get("Content-Type"), "json") {
+		return ""
+	}
+
+	data, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = ioutil.NopCloser(bytes.NewReader(data))
+	if err != nil {
+		return ""
+	}
+
+	var body struct {
+		CorrelationId string `json:"correlationId"`
+	}
+	json.Unmarshal(data, &body)
+	return body.CorrelationId
+}
@@ -0,0 +1,101 @@
+package provider
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/harness/terraform-provider-harness/internal"
+	"github.com/hashicorp/go-retryablehttp"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/require"
+)
+
+func readAuditLog(t *testing.T, path string) []map[string]interface{} {
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+
+	var entries []map[string]interface{}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		entry := map[string]interface{}{}
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &entry))
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+func TestAuditLog(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == http.MethodPost {
+			w.WriteHeader(http.StatusBadRequest)
+		}
+		w.Write([]byte(`{"status":"SUCCESS","correlationId":"abc-123","data":{}}`))
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "audit.log")
+	d := schema.TestResourceDataRaw(t, Provider("dev")().Schema, map[string]interface{}{
+		"account_id":     "test",
+		"audit_log_path": path,
+	})
+	transport, err := getTransport(d)
+	require.NoError(t, err)
+	c := getHttpClient(d, transport)
+	c.RetryMax = 0
+
+	ctx := internal.WithResourceType(context.Background(), "harness_platform_secret_text")
+	body := `{"secret":{"identifier":"test","tags":{"env":"dev"},"spec":{"valueType":"Inline","value":"s3cr3t"}},"auth_token":"t0k3n"}`
+	req, err := retryablehttp.NewRequest(http.MethodPost, server.URL+"/ng/api/v2/secrets", strings.NewReader(body))
+	require.NoError(t, err)
+	resp, err := c.Do(req.WithContext(ctx))
+	require.NoError(t, err)
+
+	// The client must still be able to read the response body.
+	data, err := ioutil.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.Contains(t, string(data), "abc-123")
+
+	_, err = c.Get(server.URL + "/ng/api/user/currentUser")
+	require.NoError(t, err)
+
+	raw, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	require.NotContains(t, string(raw), "s3cr3t")
+	require.NotContains(t, string(raw), "t0k3n")
+
+	entries := readAuditLog(t, path)
+	require.Len(t, entries, 2)
+
+	require.Equal(t, "POST", entries[0]["method"])
+	require.Equal(t, "/ng/api/v2/secrets", entries[0]["path"])
+	require.Equal(t, float64(http.StatusBadRequest), entries[0]["status"])
+	require.Equal(t, "abc-123", entries[0]["correlation_id"])
+	require.Equal(t, "harness_platform_secret_text", entries[0]["resource_type"])
+	require.Contains(t, entries[0], "duration_ms")
+	require.Len(t, entries[0], 7)
+
+	require.Equal(t, "GET", entries[1]["method"])
+	require.NotContains(t, entries[1], "resource_type")
+}
+
+func TestAuditLogDisabled(t *testing.T) {
+	require.Equal(t, http.DefaultTransport, mustAuditTransport(t, ""))
+	_, err := newAuditTransport(filepath.Join(t.TempDir(), "missing", "audit.log"), http.DefaultTransport)
+	require.Error(t, err)
+}
+
+func mustAuditTransport(t *testing.T, path string) http.RoundTripper {
+	transport, err := newAuditTransport(path, http.DefaultTransport)
+	require.NoError(t, err)
+	return transport
+}
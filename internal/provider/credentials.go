@@ -0,0 +1,113 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/harness/harness-go-sdk/harness/cd"
+	"github.com/harness/harness-go-sdk/harness/nextgen"
+	"github.com/hashicorp/go-retryablehttp"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+)
+
+// cdCredentialsQuery is a lightweight query used to check that the CD api key is accepted.
+const cdCredentialsQuery = `query { applications(limit: 1) { nodes { id } } }`
+
+// credentialsCheckTimeout bounds each credentials check, so an unreachable endpoint only delays
+// configure briefly before it is reported.
+const credentialsCheckTimeout = 10 * time.Second
+
+// getCredentialsCheckClient returns the client used to check the api keys. It doesn't retry, as a
+// failed check is only reported as a warning and shouldn't wait out the retry policy.
+func getCredentialsCheckClient(transport http.RoundTripper) *retryablehttp.Client {
+	httpClient := retryablehttp.NewClient()
+	httpClient.HTTPClient.Transport = transport
+	httpClient.HTTPClient.Timeout = credentialsCheckTimeout
+	httpClient.RetryMax = 0
+	return httpClient
+}
+
+// validateCredentials checks every configured api key against the Harness API so a bad key is
+// reported during configure instead of on the first resource that is read.
+func validateCredentials(ctx context.Context, creds *credentials, httpClient *retryablehttp.Client, version string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if creds.ApiKey != "" {
+		c, err := getCDClient(creds, httpClient, version)
+		if err != nil {
+			return diag.Errorf("error creating CD client: %s", err)
+		}
+		diags = append(diags, validateCDCredentials(c)...)
+	}
+
+	if creds.PlatformApiKey != "" {
+		diags = append(diags, validatePLCredentials(ctx, getPLClient(creds, httpClient, version))...)
+	}
+
+	return diags
+}
+
+func validateCDCredentials(c *cd.ApiClient) diag.Diagnostics {
+	req, err := c.NewGraphQLRequest(&cd.GraphQLQuery{Query: cdCredentialsQuery})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	resp, err := c.Configuration.HTTPClient.Do(req)
+	if err != nil {
+		return credentialsCheckFailed("api_key", err)
+	}
+	defer resp.Body.Close()
+
+	return checkCredentialsResponse("api_key", c.Configuration.AccountId, resp)
+}
+
+// validatePLCredentials reads the configured account, which both user and service account tokens
+// can do, unlike reading the current user.
+func validatePLCredentials(ctx context.Context, c *nextgen.APIClient) diag.Diagnostics {
+	c, ctx = c.WithAuthContext(ctx)
+
+	_, httpResp, err := c.AccountsApi.GetAccountNG(ctx, c.AccountId)
+	if httpResp != nil {
+		return checkCredentialsResponse("platform_api_key", c.AccountId, httpResp)
+	}
+
+	if err != nil {
+		return credentialsCheckFailed("platform_api_key", err)
+	}
+
+	return nil
+}
+
+func checkCredentialsResponse(key string, accountId string, resp *http.Response) diag.Diagnostics {
+	switch resp.StatusCode {
+	case http.StatusUnauthorized:
+		return diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("Invalid %s", key),
+			Detail: fmt.Sprintf("The Harness API rejected the configured `%s` (%s). Check that the key is correct, has not expired and belongs to account %q.\n\n"+
+				"Set `skip_credentials_validation = true` to skip this check.", key, resp.Status, accountId),
+		}}
+	case http.StatusForbidden:
+		return diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("Insufficient permissions for %s", key),
+			Detail: fmt.Sprintf("The Harness API accepted the configured `%s` but denied it access to account %q (%s). Check that the user or service account that owns the key has a role in the account.\n\n"+
+				"Set `skip_credentials_validation = true` to skip this check.", key, accountId, resp.Status),
+		}}
+	}
+
+	return nil
+}
+
+// credentialsCheckFailed is returned when the check itself could not complete, e.g. because the
+// endpoint is unreachable. It is only a warning since the key may still be valid.
+func credentialsCheckFailed(key string, err error) diag.Diagnostics {
+	return diag.Diagnostics{{
+		Severity: diag.Warning,
+		Summary:  fmt.Sprintf("Unable to validate %s", key),
+		Detail:   err.Error(),
+	}}
+}
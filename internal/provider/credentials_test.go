@@ -0,0 +1,155 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/harness/terraform-provider-harness/internal"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/require"
+)
+
+// newCredentialsServer returns a server that rejects every request with the given api key, and
+// denies the requests made with the `forbidden` key.
+func newCredentialsServer(t *testing.T, badKey string) (*httptest.Server, *int32) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		switch r.Header.Get("x-api-key") {
+		case badKey:
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		case "forbidden":
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/graphql":
+			w.Write([]byte(`{"data":{"applications":{"nodes":[]}}}`))
+		case "/ng/api/accounts/test_account":
+			w.Write([]byte(`{"status":"SUCCESS","data":{"identifier":"test_account"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server, &calls
+}
+
+func configureTestProvider(t *testing.T, endpoint string, config map[string]interface{}) diag.Diagnostics {
+	raw := map[string]interface{}{
+		"endpoint":                endpoint,
+		"account_id":              "test_account",
+		"shared_credentials_file": filepath.Join(t.TempDir(), "credentials"),
+	}
+	for k, v := range config {
+		raw[k] = v
+	}
+	return Provider("dev")().Configure(context.Background(), terraform.NewResourceConfigRaw(raw))
+}
+
+func TestConfigureValidatesCredentials(t *testing.T) {
+	server, _ := newCredentialsServer(t, "bad")
+
+	diags := configureTestProvider(t, server.URL, map[string]interface{}{
+		"api_key":          "good",
+		"platform_api_key": "good",
+	})
+	require.False(t, diags.HasError())
+
+	diags = configureTestProvider(t, server.URL, map[string]interface{}{
+		"api_key":          "good",
+		"platform_api_key": "bad",
+	})
+	require.True(t, diags.HasError())
+	require.Len(t, diags, 1)
+	require.Equal(t, "Invalid platform_api_key", diags[0].Summary)
+
+	diags = configureTestProvider(t, server.URL, map[string]interface{}{
+		"api_key": "bad",
+	})
+	require.True(t, diags.HasError())
+	require.Equal(t, "Invalid api_key", diags[0].Summary)
+
+	// Valid keys without access to the account are reported separately.
+	diags = configureTestProvider(t, server.URL, map[string]interface{}{
+		"platform_api_key": "forbidden",
+	})
+	require.True(t, diags.HasError())
+	require.Len(t, diags, 1)
+	require.Equal(t, "Insufficient permissions for platform_api_key", diags[0].Summary)
+}
+
+func TestConfigureSkipCredentialsValidation(t *testing.T) {
+	server, calls := newCredentialsServer(t, "bad")
+
+	diags := configureTestProvider(t, server.URL, map[string]interface{}{
+		"api_key":                     "bad",
+		"platform_api_key":            "bad",
+		"skip_credentials_validation": true,
+	})
+	require.False(t, diags.HasError())
+	require.Equal(t, int32(0), atomic.LoadInt32(calls))
+}
+
+func TestConfigureOnlyConfiguredClients(t *testing.T) {
+	server, calls := newCredentialsServer(t, "bad")
+
+	p := Provider("dev")()
+	diags := p.Configure(context.Background(), terraform.NewResourceConfigRaw(map[string]interface{}{
+		"endpoint":                    server.URL,
+		"account_id":                  "test_account",
+		"platform_api_key":            "good",
+		"shared_credentials_file":     filepath.Join(t.TempDir(), "credentials"),
+		"skip_credentials_validation": true,
+	}))
+	require.False(t, diags.HasError())
+
+	session := p.Meta().(*internal.Session)
+	require.True(t, session.HasPLClient())
+	require.False(t, session.HasCDClient())
+	require.Nil(t, session.GetCDClient())
+	require.Equal(t, int32(0), atomic.LoadInt32(calls))
+
+	c, _ := session.GetPlatformClient()
+	require.Equal(t, "good", c.ApiKey)
+}
+
+func TestConfigureCredentialsCheckDoesNotRetry(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	t.Cleanup(server.Close)
+
+	retry := []interface{}{map[string]interface{}{"max_retries": 5, "min_wait_seconds": 5}}
+
+	start := time.Now()
+	diags := configureTestProvider(t, server.URL, map[string]interface{}{
+		"platform_api_key": "good",
+		"retry":            retry,
+	})
+	require.False(t, diags.HasError())
+	require.Equal(t, int32(1), atomic.LoadInt32(&calls))
+	require.Less(t, time.Since(start), 5*time.Second)
+
+	// An unreachable endpoint is reported as a warning without waiting for retries.
+	server.Close()
+	start = time.Now()
+	diags = configureTestProvider(t, server.URL, map[string]interface{}{
+		"platform_api_key": "good",
+		"retry":            retry,
+	})
+	require.False(t, diags.HasError())
+	require.Len(t, diags, 1)
+	require.Equal(t, "Unable to validate platform_api_key", diags[0].Summary)
+	require.Less(t, time.Since(start), 5*time.Second)
+}
@@ -0,0 +1,173 @@
+package provider
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	hh "github.com/harness/harness-go-sdk/harness/helpers"
+	"github.com/harness/harness-go-sdk/harness/utils"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	defaultProfileName           = "default"
+	defaultSharedCredentialsFile = "~/.harness/credentials"
+	profileEnvVar                = "HARNESS_PROFILE"
+	sharedCredentialsFileEnvVar  = "HARNESS_SHARED_CREDENTIALS_FILE"
+)
+
+// credentials holds the connection settings used to build the API clients.
+type credentials struct {
+	Endpoint       string `yaml:"endpoint"`
+	AccountId      string `yaml:"account_id"`
+	ApiKey         string `yaml:"api_key"`
+	PlatformApiKey string `yaml:"platform_api_key"`
+}
+
+// getCredentials resolves the connection settings. Values set in the provider configuration take
+// precedence over the HARNESS_* environment variables, which take precedence over the profile
+// read from the shared credentials file.
+func getCredentials(d *schema.ResourceData) (*credentials, error) {
+	creds := &credentials{
+		Endpoint:       d.Get("endpoint").(string),
+		AccountId:      d.Get("account_id").(string),
+		ApiKey:         d.Get("api_key").(string),
+		PlatformApiKey: d.Get("platform_api_key").(string),
+	}
+
+	profileName := d.Get("profile").(string)
+	explicitProfile := profileName != ""
+	if !explicitProfile {
+		profileName = defaultProfileName
+	}
+
+	profile, err := loadProfile(d.Get("shared_credentials_file").(string), profileName)
+	if err != nil {
+		// The default profile is optional, so a missing file or profile is only an error when
+		// a profile was requested explicitly.
+		if explicitProfile {
+			return nil, err
+		}
+		profile = &credentials{}
+	}
+
+	creds.Endpoint = utils.CoalesceStr(utils.CoalesceStr(creds.Endpoint, profile.Endpoint), utils.BaseUrl)
+	creds.AccountId = utils.CoalesceStr(creds.AccountId, profile.AccountId)
+	creds.ApiKey = utils.CoalesceStr(creds.ApiKey, profile.ApiKey)
+	creds.PlatformApiKey = utils.CoalesceStr(creds.PlatformApiKey, profile.PlatformApiKey)
+
+	if creds.AccountId == "" {
+		return nil, fmt.Errorf("account_id must be set in the provider configuration, the `%s` environment variable or the credentials profile", hh.EnvVars.AccountId.String())
+	}
+
+	return creds, nil
+}
+
+// loadProfile reads the named profile from the shared credentials file. Files with a .yaml or .yml
+// extension are read as YAML, anything else is read as INI.
+func loadProfile(path string, name string) (*credentials, error) {
+	path, err := expandHomeDir(path)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading shared credentials file: %s", err)
+	}
+
+	var profiles map[string]*credentials
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		profiles, err = parseYamlProfiles(data)
+	default:
+		profiles, err = parseIniProfiles(data)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("error parsing shared credentials file %s: %s", path, err)
+	}
+
+	profile, ok := profiles[name]
+	if !ok || profile == nil {
+		return nil, fmt.Errorf("profile %q not found in shared credentials file %s", name, path)
+	}
+
+	return profile, nil
+}
+
+// parseYamlProfiles reads profiles from a YAML document of the form:
+//
+//	profiles:
+//	  default:
+//	    account_id: ...
+//	    platform_api_key: ...
+func parseYamlProfiles(data []byte) (map[string]*credentials, error) {
+	doc := map[string]map[string]*credentials{}
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	return doc["profiles"], nil
+}
+
+// parseIniProfiles reads profiles from an INI document where each section is a profile.
+func parseIniProfiles(data []byte) (map[string]*credentials, error) {
+	profiles := map[string]*credentials{}
+	var current *credentials
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		line := strings.TrimSpace(scanner.Text())
+
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			name := strings.TrimSpace(line[1 : len(line)-1])
+			current = &credentials{}
+			profiles[name] = current
+			continue
+		}
+
+		parts := strings.SplitN(line, "=", 2)
+		if len(parts) != 2 || current == nil {
+			return nil, fmt.Errorf("invalid entry on line %d", lineNum)
+		}
+
+		value := strings.Trim(strings.TrimSpace(parts[1]), `"'`)
+
+		switch strings.TrimSpace(parts[0]) {
+		case "endpoint":
+			current.Endpoint = value
+		case "account_id":
+			current.AccountId = value
+		case "api_key":
+			current.ApiKey = value
+		case "platform_api_key":
+			current.PlatformApiKey = value
+		}
+	}
+
+	return profiles, scanner.Err()
+}
+
+func expandHomeDir(path string) (string, error) {
+	if path != "~" && !strings.HasPrefix(path, "~/") {
+		return path, nil
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(home, strings.TrimPrefix(path, "~")), nil
+}
@@ -0,0 +1,108 @@
+package provider
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	hh "github.com/harness/harness-go-sdk/harness/helpers"
+	"github.com/harness/harness-go-sdk/harness/utils"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/require"
+)
+
+const testIniCredentials = `
+# SaaS account
+[default]
+account_id       = saas_account
+platform_api_key = saas_key
+
+[onprem]
+endpoint = "https://harness.example.com/gateway"
+account_id = onprem_account
+api_key = onprem_cd_key
+platform_api_key = onprem_key
+`
+
+const testYamlCredentials = `
+profiles:
+  default:
+    account_id: saas_account
+    platform_api_key: saas_key
+  onprem:
+    endpoint: https://harness.example.com/gateway
+    account_id: onprem_account
+    api_key: onprem_cd_key
+    platform_api_key: onprem_key
+`
+
+func writeCredentialsFile(t *testing.T, name string, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, ioutil.WriteFile(path, []byte(content), 0600))
+	return path
+}
+
+func TestLoadProfile(t *testing.T) {
+	for _, path := range []string{
+		writeCredentialsFile(t, "credentials", testIniCredentials),
+		writeCredentialsFile(t, "credentials.yaml", testYamlCredentials),
+	} {
+		profile, err := loadProfile(path, "onprem")
+		require.NoError(t, err)
+		require.Equal(t, &credentials{
+			Endpoint:       "https://harness.example.com/gateway",
+			AccountId:      "onprem_account",
+			ApiKey:         "onprem_cd_key",
+			PlatformApiKey: "onprem_key",
+		}, profile)
+
+		_, err = loadProfile(path, "missing")
+		require.ErrorContains(t, err, `profile "missing" not found`)
+	}
+
+	_, err := loadProfile(writeCredentialsFile(t, "bad", "account_id = x"), "default")
+	require.ErrorContains(t, err, "line 1")
+}
+
+func TestGetCredentialsPrecedence(t *testing.T) {
+	path := writeCredentialsFile(t, "credentials", testIniCredentials)
+	t.Setenv(hh.EnvVars.AccountId.String(), "")
+	t.Setenv(hh.EnvVars.Endpoint.String(), "")
+	t.Setenv(hh.EnvVars.ApiKey.String(), "")
+	t.Setenv(hh.EnvVars.PlatformApiKey.String(), "env_key")
+	t.Setenv(profileEnvVar, "")
+
+	d := schema.TestResourceDataRaw(t, Provider("dev")().Schema, map[string]interface{}{
+		"shared_credentials_file": path,
+	})
+	creds, err := getCredentials(d)
+	require.NoError(t, err)
+	require.Equal(t, "saas_account", creds.AccountId)
+	require.Equal(t, "env_key", creds.PlatformApiKey)
+	require.Equal(t, utils.BaseUrl, creds.Endpoint)
+
+	d = schema.TestResourceDataRaw(t, Provider("dev")().Schema, map[string]interface{}{
+		"shared_credentials_file": path,
+		"profile":                 "onprem",
+		"account_id":              "explicit_account",
+	})
+	creds, err = getCredentials(d)
+	require.NoError(t, err)
+	require.Equal(t, "explicit_account", creds.AccountId)
+	require.Equal(t, "onprem_cd_key", creds.ApiKey)
+	require.Equal(t, "env_key", creds.PlatformApiKey)
+	require.Equal(t, "https://harness.example.com/gateway", creds.Endpoint)
+
+	d = schema.TestResourceDataRaw(t, Provider("dev")().Schema, map[string]interface{}{
+		"shared_credentials_file": path,
+		"profile":                 "missing",
+	})
+	_, err = getCredentials(d)
+	require.Error(t, err)
+
+	d = schema.TestResourceDataRaw(t, Provider("dev")().Schema, map[string]interface{}{
+		"shared_credentials_file": filepath.Join(t.TempDir(), "missing"),
+	})
+	_, err = getCredentials(d)
+	require.ErrorContains(t, err, "account_id must be set")
+}
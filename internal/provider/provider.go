@@ -3,15 +3,15 @@ package provider
 import (
 	"context"
 	"fmt"
-	"log"
+	"net/http"
 
 	"github.com/harness/terraform-provider-harness/internal/service/platform/gitops/agent_yaml"
 
 	"github.com/harness/harness-go-sdk/harness"
 	"github.com/harness/harness-go-sdk/harness/cd"
-	"github.com/harness/harness-go-sdk/harness/helpers"
+	hh "github.com/harness/harness-go-sdk/harness/helpers"
 	"github.com/harness/harness-go-sdk/harness/nextgen"
-	"github.com/harness/harness-go-sdk/harness/utils"
+	"github.com/harness/terraform-provider-harness/helpers"
 	"github.com/harness/terraform-provider-harness/internal"
 	"github.com/harness/terraform-provider-harness/internal/service/cd/account"
 	"github.com/harness/terraform-provider-harness/internal/service/cd/application"
@@ -51,11 +51,11 @@ import (
 	"github.com/harness/terraform-provider-harness/internal/service/platform/usergroup"
 	"github.com/harness/terraform-provider-harness/internal/service/platform/variables"
 
-	"github.com/hashicorp/go-cleanhttp"
 	"github.com/hashicorp/go-retryablehttp"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/logging"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func init() {
@@ -76,225 +76,345 @@ func init() {
 
 func Provider(version string) func() *schema.Provider {
 	return func() *schema.Provider {
+		// Next Gen and First Gen types are registered separately since they need different api keys
+		// and only Next Gen types support the platform features, such as default tags.
+		nextGenDataSources := map[string]*schema.Resource{
+			"harness_platform_connector":                     connector.DataSourceConnector(),
+			"harness_platform_connector_appdynamics":         connector.DatasourceConnectorAppDynamics(),
+			"harness_platform_connector_artifactory":         connector.DatasourceConnectorArtifactory(),
+			"harness_platform_connector_aws_secret_manager":  connector.DatasourceConnectorAwsSM(),
+			"harness_platform_connector_aws":                 connector.DatasourceConnectorAws(),
+			"harness_platform_connector_awscc":               connector.DatasourceConnectorAwsCC(),
+			"harness_platform_connector_awskms":              connector.DatasourceConnectorAwsKms(),
+			"harness_platform_connector_bitbucket":           connector.DatasourceConnectorBitbucket(),
+			"harness_platform_connector_datadog":             connector.DatasourceConnectorDatadog(),
+			"harness_platform_connector_docker":              connector.DatasourceConnectorDocker(),
+			"harness_platform_connector_dynatrace":           connector.DatasourceConnectorDynatrace(),
+			"harness_platform_connector_gcp":                 connector.DatasourceConnectorGcp(),
+			"harness_platform_connector_gcp_secret_manager":  connector.DatasourceConnectorGcpSM(),
+			"harness_platform_connector_git":                 connector.DatasourceConnectorGit(),
+			"harness_platform_connector_github":              connector.DatasourceConnectorGithub(),
+			"harness_platform_connector_gitlab":              connector.DatasourceConnectorGitlab(),
+			"harness_platform_connector_helm":                connector.DatasourceConnectorHelm(),
+			"harness_platform_connector_jira":                connector.DatasourceConnectorJira(),
+			"harness_platform_connector_kubernetes":          connector.DatasourceConnectorKubernetes(),
+			"harness_platform_connector_nexus":               connector.DatasourceConnectorNexus(),
+			"harness_platform_connector_pagerduty":           connector.DatasourceConnectorPagerDuty(),
+			"harness_platform_connector_prometheus":          connector.DatasourceConnectorPrometheus(),
+			"harness_platform_connector_splunk":              connector.DatasourceConnectorSplunk(),
+			"harness_platform_connector_sumologic":           connector.DatasourceConnectorSumologic(),
+			"harness_platform_current_user":                  pl_user.DataSourceCurrentUser(),
+			"harness_platform_environment":                   pl_environment.DataSourceEnvironment(),
+			"harness_platform_environment_group":             pl_environment_group.DataSourceEnvironmentGroup(),
+			"harness_platform_environment_clusters_mapping":  pl_environment_clusters_mapping.DataSourceEnvironmentClustersMapping(),
+			"harness_platform_environment_service_overrides": pl_environment_service_overrides.DataSourceEnvironmentServiceOverrides(),
+			"harness_platform_gitops_agent":                  gitops_agent.DataSourceGitopsAgent(),
+			"harness_platform_gitops_agent_deploy_yaml":      agent_yaml.DataSourceGitopsAgentDeployYaml(),
+			"harness_platform_gitops_applications":           gitops_applications.DataSourceGitopsApplications(),
+			"harness_platform_gitops_cluster":                gitops_cluster.DataSourceGitopsCluster(),
+			"harness_platform_gitops_repository":             gitops_repository.DataSourceGitopsRepository(),
+			"harness_platform_infrastructure":                pl_infrastructure.DataSourceInfrastructure(),
+			"harness_environment_service_overrides":          pl_environment_service_overrides.DataSourceEnvironmentServiceOverrides(),
+			"harness_platform_input_set":                     input_set.DataSourceInputSet(),
+			"harness_platform_organization":                  organization.DataSourceOrganization(),
+			"harness_platform_pipeline":                      pipeline.DataSourcePipeline(),
+			"harness_platform_permissions":                   pl_permissions.DataSourcePermissions(),
+			"harness_platform_project":                       project.DataSourceProject(),
+			"harness_platform_service":                       pl_service.DataSourceService(),
+			"harness_platform_usergroup":                     usergroup.DataSourceUserGroup(),
+			"harness_platform_secret_text":                   secret.DataSourceSecretText(),
+			"harness_platform_secret_file":                   secret.DataSourceSecretFile(),
+			"harness_platform_secret_sshkey":                 secret.DataSourceSecretSSHKey(),
+			"harness_platform_roles":                         roles.DataSourceRoles(),
+			"harness_platform_resource_group":                resource_group.DataSourceResourceGroup(),
+			"harness_platform_service_account":               service_account.DataSourceServiceAccount(),
+			"harness_platform_triggers":                      triggers.DataSourceTriggers(),
+			"harness_platform_role_assignments":              role_assignments.DataSourceRoleAssignments(),
+			"harness_platform_variables":                     variables.DataSourceVariables(),
+			"harness_platform_connector_vault":               connector.DataSourceConnectorVault(),
+			"harness_platform_filters":                       filters.DataSourceFilters(),
+		}
+
+		firstGenDataSources := map[string]*schema.Resource{
+			"harness_application":     application.DataSourceApplication(),
+			"harness_current_account": account.DataSourceCurrentAccountConnector(),
+			"harness_delegate":        delegate.DataSourceDelegate(),
+			"harness_delegate_ids":    delegate.DataSourceDelegateIds(),
+			"harness_encrypted_text":  secrets.DataSourceEncryptedText(),
+			"harness_environment":     environment.DataSourceEnvironment(),
+			"harness_git_connector":   cd_connector.DataSourceGitConnector(),
+			"harness_secret_manager":  secrets.DataSourceSecretManager(),
+			"harness_service":         service.DataSourceService(),
+			"harness_ssh_credential":  secrets.DataSourceSshCredential(),
+			"harness_sso_provider":    sso.DataSourceSSOProvider(),
+			"harness_user_group":      user.DataSourceUserGroup(),
+			"harness_user":            user.DataSourceUser(),
+			"harness_yaml_config":     yamlconfig.DataSourceYamlConfig(),
+		}
+
+		nextGenResources := map[string]*schema.Resource{
+			"harness_platform_connector_appdynamics":          connector.ResourceConnectorAppDynamics(),
+			"harness_platform_connector_artifactory":          connector.ResourceConnectorArtifactory(),
+			"harness_platform_connector_aws_secret_manager":   connector.ResourceConnectorAwsSM(),
+			"harness_platform_connector_aws":                  connector.ResourceConnectorAws(),
+			"harness_platform_connector_awscc":                connector.ResourceConnectorAwsCC(),
+			"harness_platform_connector_awskms":               connector.ResourceConnectorAwsKms(),
+			"harness_platform_connector_bitbucket":            connector.ResourceConnectorBitbucket(),
+			"harness_platform_connector_datadog":              connector.ResourceConnectorDatadog(),
+			"harness_platform_connector_docker":               connector.ResourceConnectorDocker(),
+			"harness_platform_connector_dynatrace":            connector.ResourceConnectorDynatrace(),
+			"harness_platform_connector_gcp":                  connector.ResourceConnectorGcp(),
+			"harness_platform_connector_gcp_secret_manager":   connector.ResourceConnectorGCPSecretManager(),
+			"harness_platform_connector_git":                  connector.ResourceConnectorGit(),
+			"harness_platform_connector_github":               connector.ResourceConnectorGithub(),
+			"harness_platform_connector_gitlab":               connector.ResourceConnectorGitlab(),
+			"harness_platform_connector_helm":                 connector.ResourceConnectorHelm(),
+			"harness_platform_connector_jira":                 connector.ResourceConnectorJira(),
+			"harness_platform_connector_kubernetes":           connector.ResourceConnectorK8s(),
+			"harness_platform_connector_newrelic":             connector.ResourceConnectorNewRelic(),
+			"harness_platform_connector_nexus":                connector.ResourceConnectorNexus(),
+			"harness_platform_connector_pagerduty":            connector.ResourceConnectorPagerDuty(),
+			"harness_platform_connector_prometheus":           connector.ResourceConnectorPrometheus(),
+			"harness_platform_connector_splunk":               connector.ResourceConnectorSplunk(),
+			"harness_platform_connector_sumologic":            connector.ResourceConnectorSumologic(),
+			"harness_platform_connector_yaml":                 connector.ResourceConnectorYaml(),
+			"harness_platform_environment":                    pl_environment.ResourceEnvironment(),
+			"harness_platform_environment_group":              pl_environment_group.ResourceEnvironmentGroup(),
+			"harness_platform_environment_clusters_mapping":   pl_environment_clusters_mapping.ResourceEnvironmentClustersMapping(),
+			"harness_platform_environment_service_overrides":  pl_environment_service_overrides.ResourceEnvironmentServiceOverrides(),
+			"harness_platform_gitops_agent":                   gitops_agent.ResourceGitopsAgent(),
+			"harness_platform_gitops_applications":            gitops_applications.ResourceGitopsApplication(),
+			"harness_platform_gitops_cluster":                 gitops_cluster.ResourceGitopsCluster(),
+			"harness_platform_gitops_repository":              gitops_repository.ResourceGitopsRepositories(),
+			"harness_platform_infrastructure":                 pl_infrastructure.ResourceInfrastructure(),
+			"harness_environment_service_overrides":           pl_environment_service_overrides.ResourceEnvironmentServiceOverrides(),
+			"harness_platform_input_set":                      input_set.ResourceInputSet(),
+			"harness_platform_organization":                   organization.ResourceOrganization(),
+			"harness_platform_pipeline":                       pipeline.ResourcePipeline(),
+			"harness_platform_project":                        project.ResourceProject(),
+			"harness_platform_service":                        pl_service.ResourceService(),
+			"harness_platform_usergroup":                      usergroup.ResourceUserGroup(),
+			"harness_platform_secret_text":                    secret.ResourceSecretText(),
+			"harness_platform_secret_file":                    secret.ResourceSecretFile(),
+			"harness_platform_secret_sshkey":                  secret.ResourceSecretSSHKey(),
+			"harness_platform_roles":                          roles.ResourceRoles(),
+			"harness_platform_resource_group":                 resource_group.ResourceResourceGroup(),
+			"harness_platform_service_account":                service_account.ResourceServiceAccount(),
+			"harness_platform_triggers":                       triggers.ResourceTriggers(),
+			"harness_platform_role_assignments":               role_assignments.ResourceRoleAssignments(),
+			"harness_platform_variables":                      variables.ResourceVariables(),
+			"harness_platform_connector_vault":                connector.ResourceConnectorVault(),
+			"harness_platform_filters":                        filters.ResourceFilters(),
+			"harness_platform_connector_azure_cloud_provider": connector.ResourceConnectorAzureCloudProvider(),
+		}
+
+		firstGenResources := map[string]*schema.Resource{
+			"harness_add_user_to_group":         user.ResourceAddUserToGroup(),
+			"harness_application_gitsync":       application.ResourceApplicationGitSync(),
+			"harness_application":               application.ResourceApplication(),
+			"harness_delegate_approval":         delegate.ResourceDelegateApproval(),
+			"harness_cloudprovider_aws":         cloudprovider.ResourceCloudProviderAws(),
+			"harness_cloudprovider_azure":       cloudprovider.ResourceCloudProviderAzure(),
+			"harness_cloudprovider_datacenter":  cloudprovider.ResourceCloudProviderDataCenter(),
+			"harness_cloudprovider_gcp":         cloudprovider.ResourceCloudProviderGcp(),
+			"harness_cloudprovider_kubernetes":  cloudprovider.ResourceCloudProviderK8s(),
+			"harness_cloudprovider_spot":        cloudprovider.ResourceCloudProviderSpot(),
+			"harness_cloudprovider_tanzu":       cloudprovider.ResourceCloudProviderTanzu(),
+			"harness_encrypted_text":            secrets.ResourceEncryptedText(),
+			"harness_environment":               environment.ResourceEnvironment(),
+			"harness_git_connector":             cd_connector.ResourceGitConnector(),
+			"harness_infrastructure_definition": environment.ResourceInfraDefinition(),
+			"harness_service_ami":               service.ResourceAMIService(),
+			"harness_service_aws_codedeploy":    service.ResourceAWSCodeDeployService(),
+			"harness_service_aws_lambda":        service.ResourceAWSLambdaService(),
+			"harness_service_ecs":               service.ResourceECSService(),
+			"harness_service_helm":              service.ResourceHelmService(),
+			"harness_service_kubernetes":        service.ResourceKubernetesService(),
+			"harness_service_ssh":               service.ResourceSSHService(),
+			"harness_service_tanzu":             service.ResourcePCFService(),
+			"harness_service_winrm":             service.ResourceWinRMService(),
+			"harness_ssh_credential":            secrets.ResourceSSHCredential(),
+			"harness_user_group":                user.ResourceUserGroup(),
+			"harness_user_group_permissions":    user.ResourceUserGroupPermissions(),
+			"harness_user":                      user.ResourceUser(),
+			"harness_yaml_config":               yamlconfig.ResourceYamlConfig(),
+		}
+
 		p := &schema.Provider{
 			Schema: map[string]*schema.Schema{
 				"endpoint": {
-					Description: fmt.Sprintf("The URL of the Harness API endpoint. The default is `https://app.harness.io/gateway`. This can also be set using the `%s` environment variable.", helpers.EnvVars.Endpoint.String()),
+					Description: fmt.Sprintf("The URL of the Harness API endpoint. The default is `https://app.harness.io/gateway`. This can also be set using the `%s` environment variable or the credentials profile.", hh.EnvVars.Endpoint.String()),
 					Type:        schema.TypeString,
-					Required:    true,
-					DefaultFunc: schema.EnvDefaultFunc(helpers.EnvVars.Endpoint.String(), utils.BaseUrl),
+					Optional:    true,
+					DefaultFunc: schema.EnvDefaultFunc(hh.EnvVars.Endpoint.String(), nil),
 				},
 				"account_id": {
-					Description: fmt.Sprintf("The Harness account id. This can also be set using the `%s` environment variable.", helpers.EnvVars.AccountId.String()),
+					Description: fmt.Sprintf("The Harness account id. This can also be set using the `%s` environment variable or the credentials profile.", hh.EnvVars.AccountId.String()),
 					Type:        schema.TypeString,
-					Required:    true,
-					DefaultFunc: schema.EnvDefaultFunc(helpers.EnvVars.AccountId.String(), nil),
+					Optional:    true,
+					DefaultFunc: schema.EnvDefaultFunc(hh.EnvVars.AccountId.String(), nil),
 				},
 				"api_key": {
-					Description: fmt.Sprintf("The Harness API key. This can also be set using the `%s` environment variable.", helpers.EnvVars.ApiKey.String()),
+					Description: fmt.Sprintf("The Harness API key. This can also be set using the `%s` environment variable or the credentials profile.", hh.EnvVars.ApiKey.String()),
 					Type:        schema.TypeString,
 					Optional:    true,
-					DefaultFunc: schema.EnvDefaultFunc(helpers.EnvVars.ApiKey.String(), nil),
+					DefaultFunc: schema.EnvDefaultFunc(hh.EnvVars.ApiKey.String(), nil),
+				},
+				"max_requests_per_second": {
+					Description:  "Maximum number of requests per second sent to the Harness API across all resources. Requests over the limit wait for their turn instead of failing. The default of 0 disables the limit.",
+					Type:         schema.TypeInt,
+					Optional:     true,
+					Default:      0,
+					ValidateFunc: validation.IntAtLeast(0),
 				},
 				"platform_api_key": {
-					Description: fmt.Sprintf("The API key for the Harness next gen platform. This can also be set using the `%s` environment variable.", helpers.EnvVars.PlatformApiKey.String()),
+					Description: fmt.Sprintf("The API key for the Harness next gen platform. This can also be set using the `%s` environment variable or the credentials profile.", hh.EnvVars.PlatformApiKey.String()),
 					Type:        schema.TypeString,
 					Optional:    true,
-					DefaultFunc: schema.EnvDefaultFunc(helpers.EnvVars.PlatformApiKey.String(), nil),
+					DefaultFunc: schema.EnvDefaultFunc(hh.EnvVars.PlatformApiKey.String(), nil),
 				},
+				"adopt_existing": {
+					Description: "When a platform resource is created and an entity with its identifier already exists, take the existing entity into state and update it to match the configuration instead of failing. Resources can override this with their own `adopt_existing` argument.",
+					Type:        schema.TypeBool,
+					Optional:    true,
+					Default:     false,
+				},
+				"audit_log_path": {
+					Description: "Path of a file to which one JSON line is appended for every request made to the Harness API, recording the method, path, status, duration, correlation id and the resource type that made it. Request and response bodies are not logged.",
+					Type:        schema.TypeString,
+					Optional:    true,
+				},
+				"default_org_id": {
+					Description: "Organization used by resources that don't set `org_id`. Resources that support several scopes, such as connectors and secrets, only use it when they set neither `org_id` nor `project_id`. Existing ones at the account scope stay there, and new ones need a provider configuration without defaults to be created at the account scope.",
+					Type:        schema.TypeString,
+					Optional:    true,
+				},
+				"default_project_id": {
+					Description:  "Project used by resources that don't set `project_id`. Resources that support several scopes only use it when they set neither `org_id` nor `project_id`. Requires `default_org_id`.",
+					Type:         schema.TypeString,
+					Optional:     true,
+					RequiredWith: []string{"default_org_id"},
+				},
+				"default_tags": {
+					Description: "Tags added to every platform resource that supports tags. Tags should be in the form `name:value`. Tags set on a resource take precedence over these.",
+					Type:        schema.TypeSet,
+					Optional:    true,
+					Elem: &schema.Schema{
+						Type: schema.TypeString,
+					},
+				},
+				"profile": {
+					Description: fmt.Sprintf("Name of the profile to read from the shared credentials file. Settings in the provider configuration and the `HARNESS_*` environment variables take precedence over the profile. When not set, the `%s` profile is used if it exists. This can also be set using the `%s` environment variable.", defaultProfileName, profileEnvVar),
+					Type:        schema.TypeString,
+					Optional:    true,
+					DefaultFunc: schema.EnvDefaultFunc(profileEnvVar, nil),
+				},
+				"proxy_url": {
+					Description:  "URL of the HTTP proxy used for requests to the Harness API. When not set, the `HTTPS_PROXY`, `HTTP_PROXY` and `NO_PROXY` environment variables are used.",
+					Type:         schema.TypeString,
+					Optional:     true,
+					ValidateFunc: validation.IsURLWithScheme([]string{"http", "https", "socks5"}),
+				},
+				"read_cache": {
+					Description: "Cache the API responses read by data sources for the lifetime of the provider and combine identical data source requests that are in flight at the same time. Writes invalidate the cached responses of the objects they change. This speeds up plans that read the same data sources many times.",
+					Type:        schema.TypeBool,
+					Optional:    true,
+					Default:     false,
+				},
+				"retry": getRetrySchema(),
+				"shared_credentials_file": {
+					Description: fmt.Sprintf("Path to the shared credentials file. Files ending in `.yaml` or `.yml` are read as YAML, anything else as INI. The default is `%s`. This can also be set using the `%s` environment variable.", defaultSharedCredentialsFile, sharedCredentialsFileEnvVar),
+					Type:        schema.TypeString,
+					Optional:    true,
+					DefaultFunc: schema.EnvDefaultFunc(sharedCredentialsFileEnvVar, defaultSharedCredentialsFile),
+				},
+				"skip_credentials_validation": {
+					Description: "Skip checking the configured api keys against the Harness API when the provider is configured. Useful for offline plans.",
+					Type:        schema.TypeBool,
+					Optional:    true,
+					Default:     false,
+				},
+				"tls": getTLSSchema(),
 			},
-			DataSourcesMap: map[string]*schema.Resource{
-				"harness_platform_connector_appdynamics":         connector.DatasourceConnectorAppDynamics(),
-				"harness_platform_connector_artifactory":         connector.DatasourceConnectorArtifactory(),
-				"harness_platform_connector_aws_secret_manager":  connector.DatasourceConnectorAwsSM(),
-				"harness_platform_connector_aws":                 connector.DatasourceConnectorAws(),
-				"harness_platform_connector_awscc":               connector.DatasourceConnectorAwsCC(),
-				"harness_platform_connector_awskms":              connector.DatasourceConnectorAwsKms(),
-				"harness_platform_connector_bitbucket":           connector.DatasourceConnectorBitbucket(),
-				"harness_platform_connector_datadog":             connector.DatasourceConnectorDatadog(),
-				"harness_platform_connector_docker":              connector.DatasourceConnectorDocker(),
-				"harness_platform_connector_dynatrace":           connector.DatasourceConnectorDynatrace(),
-				"harness_platform_connector_gcp":                 connector.DatasourceConnectorGcp(),
-				"harness_platform_connector_gcp_secret_manager":  connector.DatasourceConnectorGcpSM(),
-				"harness_platform_connector_git":                 connector.DatasourceConnectorGit(),
-				"harness_platform_connector_github":              connector.DatasourceConnectorGithub(),
-				"harness_platform_connector_gitlab":              connector.DatasourceConnectorGitlab(),
-				"harness_platform_connector_helm":                connector.DatasourceConnectorHelm(),
-				"harness_platform_connector_jira":                connector.DatasourceConnectorJira(),
-				"harness_platform_connector_kubernetes":          connector.DatasourceConnectorKubernetes(),
-				"harness_platform_connector_nexus":               connector.DatasourceConnectorNexus(),
-				"harness_platform_connector_pagerduty":           connector.DatasourceConnectorPagerDuty(),
-				"harness_platform_connector_prometheus":          connector.DatasourceConnectorPrometheus(),
-				"harness_platform_connector_splunk":              connector.DatasourceConnectorSplunk(),
-				"harness_platform_connector_sumologic":           connector.DatasourceConnectorSumologic(),
-				"harness_platform_current_user":                  pl_user.DataSourceCurrentUser(),
-				"harness_platform_environment":                   pl_environment.DataSourceEnvironment(),
-				"harness_platform_environment_group":             pl_environment_group.DataSourceEnvironmentGroup(),
-				"harness_platform_environment_clusters_mapping":  pl_environment_clusters_mapping.DataSourceEnvironmentClustersMapping(),
-				"harness_platform_environment_service_overrides": pl_environment_service_overrides.DataSourceEnvironmentServiceOverrides(),
-				"harness_platform_gitops_agent":                  gitops_agent.DataSourceGitopsAgent(),
-				"harness_platform_gitops_agent_deploy_yaml":      agent_yaml.DataSourceGitopsAgentDeployYaml(),
-				"harness_platform_gitops_applications":           gitops_applications.DataSourceGitopsApplications(),
-				"harness_platform_gitops_cluster":                gitops_cluster.DataSourceGitopsCluster(),
-				"harness_platform_gitops_repository":             gitops_repository.DataSourceGitopsRepository(),
-				"harness_platform_infrastructure":                pl_infrastructure.DataSourceInfrastructure(),
-				"harness_environment_service_overrides":          pl_environment_service_overrides.DataSourceEnvironmentServiceOverrides(),
-				"harness_platform_input_set":                     input_set.DataSourceInputSet(),
-				"harness_platform_organization":                  organization.DataSourceOrganization(),
-				"harness_platform_pipeline":                      pipeline.DataSourcePipeline(),
-				"harness_platform_permissions":                   pl_permissions.DataSourcePermissions(),
-				"harness_platform_project":                       project.DataSourceProject(),
-				"harness_platform_service":                       pl_service.DataSourceService(),
-				"harness_platform_usergroup":                     usergroup.DataSourceUserGroup(),
-				"harness_platform_secret_text":                   secret.DataSourceSecretText(),
-				"harness_platform_secret_file":                   secret.DataSourceSecretFile(),
-				"harness_platform_secret_sshkey":                 secret.DataSourceSecretSSHKey(),
-				"harness_platform_roles":                         roles.DataSourceRoles(),
-				"harness_platform_resource_group":                resource_group.DataSourceResourceGroup(),
-				"harness_platform_service_account":               service_account.DataSourceServiceAccount(),
-				"harness_platform_triggers":                      triggers.DataSourceTriggers(),
-				"harness_platform_role_assignments":              role_assignments.DataSourceRoleAssignments(),
-				"harness_platform_variables":                     variables.DataSourceVariables(),
-				"harness_platform_connector_vault":               connector.DataSourceConnectorVault(),
-				"harness_platform_filters":                       filters.DataSourceFilters(),
-				"harness_application":                            application.DataSourceApplication(),
-				"harness_current_account":                        account.DataSourceCurrentAccountConnector(),
-				"harness_delegate":                               delegate.DataSourceDelegate(),
-				"harness_delegate_ids":                           delegate.DataSourceDelegateIds(),
-				"harness_encrypted_text":                         secrets.DataSourceEncryptedText(),
-				"harness_environment":                            environment.DataSourceEnvironment(),
-				"harness_git_connector":                          cd_connector.DataSourceGitConnector(),
-				"harness_secret_manager":                         secrets.DataSourceSecretManager(),
-				"harness_service":                                service.DataSourceService(),
-				"harness_ssh_credential":                         secrets.DataSourceSshCredential(),
-				"harness_sso_provider":                           sso.DataSourceSSOProvider(),
-				"harness_user_group":                             user.DataSourceUserGroup(),
-				"harness_user":                                   user.DataSourceUser(),
-				"harness_yaml_config":                            yamlconfig.DataSourceYamlConfig(),
-			},
-			ResourcesMap: map[string]*schema.Resource{
-				"harness_platform_connector_appdynamics":          connector.ResourceConnectorAppDynamics(),
-				"harness_platform_connector_artifactory":          connector.ResourceConnectorArtifactory(),
-				"harness_platform_connector_aws_secret_manager":   connector.ResourceConnectorAwsSM(),
-				"harness_platform_connector_aws":                  connector.ResourceConnectorAws(),
-				"harness_platform_connector_awscc":                connector.ResourceConnectorAwsCC(),
-				"harness_platform_connector_awskms":               connector.ResourceConnectorAwsKms(),
-				"harness_platform_connector_bitbucket":            connector.ResourceConnectorBitbucket(),
-				"harness_platform_connector_datadog":              connector.ResourceConnectorDatadog(),
-				"harness_platform_connector_docker":               connector.ResourceConnectorDocker(),
-				"harness_platform_connector_dynatrace":            connector.ResourceConnectorDynatrace(),
-				"harness_platform_connector_gcp":                  connector.ResourceConnectorGcp(),
-				"harness_platform_connector_gcp_secret_manager":   connector.ResourceConnectorGCPSecretManager(),
-				"harness_platform_connector_git":                  connector.ResourceConnectorGit(),
-				"harness_platform_connector_github":               connector.ResourceConnectorGithub(),
-				"harness_platform_connector_gitlab":               connector.ResourceConnectorGitlab(),
-				"harness_platform_connector_helm":                 connector.ResourceConnectorHelm(),
-				"harness_platform_connector_jira":                 connector.ResourceConnectorJira(),
-				"harness_platform_connector_kubernetes":           connector.ResourceConnectorK8s(),
-				"harness_platform_connector_newrelic":             connector.ResourceConnectorNewRelic(),
-				"harness_platform_connector_nexus":                connector.ResourceConnectorNexus(),
-				"harness_platform_connector_pagerduty":            connector.ResourceConnectorPagerDuty(),
-				"harness_platform_connector_prometheus":           connector.ResourceConnectorPrometheus(),
-				"harness_platform_connector_splunk":               connector.ResourceConnectorSplunk(),
-				"harness_platform_connector_sumologic":            connector.ResourceConnectorSumologic(),
-				"harness_platform_environment":                    pl_environment.ResourceEnvironment(),
-				"harness_platform_environment_group":              pl_environment_group.ResourceEnvironmentGroup(),
-				"harness_platform_environment_clusters_mapping":   pl_environment_clusters_mapping.ResourceEnvironmentClustersMapping(),
-				"harness_platform_environment_service_overrides":  pl_environment_service_overrides.ResourceEnvironmentServiceOverrides(),
-				"harness_platform_gitops_agent":                   gitops_agent.ResourceGitopsAgent(),
-				"harness_platform_gitops_applications":            gitops_applications.ResourceGitopsApplication(),
-				"harness_platform_gitops_cluster":                 gitops_cluster.ResourceGitopsCluster(),
-				"harness_platform_gitops_repository":              gitops_repository.ResourceGitopsRepositories(),
-				"harness_platform_infrastructure":                 pl_infrastructure.ResourceInfrastructure(),
-				"harness_environment_service_overrides":           pl_environment_service_overrides.ResourceEnvironmentServiceOverrides(),
-				"harness_platform_input_set":                      input_set.ResourceInputSet(),
-				"harness_platform_organization":                   organization.ResourceOrganization(),
-				"harness_platform_pipeline":                       pipeline.ResourcePipeline(),
-				"harness_platform_project":                        project.ResourceProject(),
-				"harness_platform_service":                        pl_service.ResourceService(),
-				"harness_platform_usergroup":                      usergroup.ResourceUserGroup(),
-				"harness_platform_secret_text":                    secret.ResourceSecretText(),
-				"harness_platform_secret_file":                    secret.ResourceSecretFile(),
-				"harness_platform_secret_sshkey":                  secret.ResourceSecretSSHKey(),
-				"harness_platform_roles":                          roles.ResourceRoles(),
-				"harness_platform_resource_group":                 resource_group.ResourceResourceGroup(),
-				"harness_platform_service_account":                service_account.ResourceServiceAccount(),
-				"harness_platform_triggers":                       triggers.ResourceTriggers(),
-				"harness_platform_role_assignments":               role_assignments.ResourceRoleAssignments(),
-				"harness_platform_variables":                      variables.ResourceVariables(),
-				"harness_platform_connector_vault":                connector.ResourceConnectorVault(),
-				"harness_platform_filters":                        filters.ResourceFilters(),
-				"harness_add_user_to_group":                       user.ResourceAddUserToGroup(),
-				"harness_application_gitsync":                     application.ResourceApplicationGitSync(),
-				"harness_application":                             application.ResourceApplication(),
-				"harness_delegate_approval":                       delegate.ResourceDelegateApproval(),
-				"harness_cloudprovider_aws":                       cloudprovider.ResourceCloudProviderAws(),
-				"harness_cloudprovider_azure":                     cloudprovider.ResourceCloudProviderAzure(),
-				"harness_cloudprovider_datacenter":                cloudprovider.ResourceCloudProviderDataCenter(),
-				"harness_cloudprovider_gcp":                       cloudprovider.ResourceCloudProviderGcp(),
-				"harness_cloudprovider_kubernetes":                cloudprovider.ResourceCloudProviderK8s(),
-				"harness_cloudprovider_spot":                      cloudprovider.ResourceCloudProviderSpot(),
-				"harness_cloudprovider_tanzu":                     cloudprovider.ResourceCloudProviderTanzu(),
-				"harness_encrypted_text":                          secrets.ResourceEncryptedText(),
-				"harness_environment":                             environment.ResourceEnvironment(),
-				"harness_git_connector":                           cd_connector.ResourceGitConnector(),
-				"harness_infrastructure_definition":               environment.ResourceInfraDefinition(),
-				"harness_service_ami":                             service.ResourceAMIService(),
-				"harness_service_aws_codedeploy":                  service.ResourceAWSCodeDeployService(),
-				"harness_service_aws_lambda":                      service.ResourceAWSLambdaService(),
-				"harness_service_ecs":                             service.ResourceECSService(),
-				"harness_service_helm":                            service.ResourceHelmService(),
-				"harness_service_kubernetes":                      service.ResourceKubernetesService(),
-				"harness_service_ssh":                             service.ResourceSSHService(),
-				"harness_service_tanzu":                           service.ResourcePCFService(),
-				"harness_service_winrm":                           service.ResourceWinRMService(),
-				"harness_ssh_credential":                          secrets.ResourceSSHCredential(),
-				"harness_user_group":                              user.ResourceUserGroup(),
-				"harness_user_group_permissions":                  user.ResourceUserGroupPermissions(),
-				"harness_user":                                    user.ResourceUser(),
-				"harness_yaml_config":                             yamlconfig.ResourceYamlConfig(),
-				"harness_platform_connector_azure_cloud_provider": connector.ResourceConnectorAzureCloudProvider(),
-			},
+			DataSourcesMap: mergeResources(nextGenDataSources, firstGenDataSources),
+			ResourcesMap:   mergeResources(nextGenResources, firstGenResources),
 		}
 
+		stateTypes := helpers.StateTypes(nextGenResources)
+		helpers.ApplyScopeDefaults(p.ResourcesMap)
+		helpers.ApplyIdentifierValidation(nextGenResources)
+		helpers.ApplyDefaultTags(nextGenResources, stateTypes)
+		helpers.ApplyGeneration(nextGenResources, helpers.NextGen, false)
+		helpers.ApplyGeneration(firstGenResources, helpers.FirstGen, false)
+		helpers.ApplyGeneration(nextGenDataSources, helpers.NextGen, true)
+		helpers.ApplyGeneration(firstGenDataSources, helpers.FirstGen, true)
+		helpers.ApplyCDErrorDiagnostics(firstGenResources)
+		helpers.ApplyCDErrorDiagnostics(firstGenDataSources)
+		helpers.ApplyAdoptExisting(nextGenResources)
+		helpers.ApplyConsistencyWait(nextGenResources)
+		helpers.ApplyTimeouts(p.ResourcesMap)
+		helpers.ApplyResourceType(p.ResourcesMap, "")
+		helpers.ApplyResourceType(p.DataSourcesMap, "data.")
+
 		p.ConfigureContextFunc = configure(version, p)
 
 		return p
 	}
 }
 
-func getHttpClient() *retryablehttp.Client {
+// mergeResources returns a map holding the types of all the given maps.
+func mergeResources(maps ...map[string]*schema.Resource) map[string]*schema.Resource {
+	result := map[string]*schema.Resource{}
+	for _, m := range maps {
+		for name, r := range m {
+			result[name] = r
+		}
+	}
+	return result
+}
+
+// getTransport builds the transport shared by the CD and NG clients so that both draw from the
+// same connection pool, rate limit and audit log.
+func getTransport(d *schema.ResourceData) (http.RoundTripper, error) {
+	transport, err := getPooledTransport(d)
+	if err != nil {
+		return nil, err
+	}
+
+	audited, err := newAuditTransport(d.Get("audit_log_path").(string), logging.NewTransport(harness.SDKName, transport))
+	if err != nil {
+		return nil, err
+	}
+
+	return newRateLimitedTransport(d.Get("max_requests_per_second").(int), audited), nil
+}
+
+func getHttpClient(d *schema.ResourceData, transport http.RoundTripper) *retryablehttp.Client {
 	httpClient := retryablehttp.NewClient()
-	httpClient.HTTPClient.Transport = logging.NewTransport(harness.SDKName, cleanhttp.DefaultPooledClient().Transport)
-	httpClient.RetryMax = 10
+	httpClient.HTTPClient.Transport = transport
+	expandRetryPolicy(d.Get("retry").([]interface{})).apply(httpClient)
 	return httpClient
 }
 
-func getCDClient(d *schema.ResourceData, version string) *cd.ApiClient {
+func getCDClient(creds *credentials, httpClient *retryablehttp.Client, version string) (*cd.ApiClient, error) {
 	cfg := cd.DefaultConfig()
-	cfg.AccountId = d.Get("account_id").(string)
-	cfg.Endpoint = d.Get("endpoint").(string)
-	cfg.APIKey = d.Get("api_key").(string)
+	cfg.AccountId = creds.AccountId
+	cfg.Endpoint = creds.Endpoint
+	cfg.APIKey = creds.ApiKey
 	cfg.UserAgent = fmt.Sprintf("terraform-provider-harness-%s", version)
-	cfg.HTTPClient = getHttpClient()
+	cfg.HTTPClient = httpClient
 	cfg.DebugLogging = logging.IsDebugOrHigher()
 
-	client, err := cd.NewClient(cfg)
-
-	if err != nil {
-		log.Printf("[WARN] error creating CD client: %s", err)
-	}
-
-	return client
+	return cd.NewClient(cfg)
 }
 
-func getPLClient(d *schema.ResourceData, version string) *nextgen.APIClient {
+func getPLClient(creds *credentials, httpClient *retryablehttp.Client, version string) *nextgen.APIClient {
 	client := nextgen.NewAPIClient(&nextgen.Configuration{
-		AccountId:    d.Get("account_id").(string),
-		BasePath:     d.Get("endpoint").(string),
-		ApiKey:       d.Get("platform_api_key").(string),
+		AccountId:    creds.AccountId,
+		BasePath:     creds.Endpoint,
+		ApiKey:       creds.PlatformApiKey,
 		UserAgent:    fmt.Sprintf("terraform-provider-harness-platform-%s", version),
-		HTTPClient:   getHttpClient(),
+		HTTPClient:   httpClient,
 		DebugLogging: logging.IsDebugOrHigher(),
 	})
 
@@ -304,11 +424,51 @@ func getPLClient(d *schema.ResourceData, version string) *nextgen.APIClient {
 // Setup the client for interacting with the Harness API
 func configure(version string, p *schema.Provider) func(context.Context, *schema.ResourceData) (interface{}, diag.Diagnostics) {
 	return func(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
-		return &internal.Session{
-			AccountId: d.Get("account_id").(string),
-			Endpoint:  d.Get("endpoint").(string),
-			CDClient:  getCDClient(d, version),
-			PLClient:  getPLClient(d, version),
-		}, nil
+		creds, err := getCredentials(d)
+		if err != nil {
+			return nil, diag.FromErr(err)
+		}
+
+		transport, err := getTransport(d)
+		if err != nil {
+			return nil, diag.Errorf("error creating http transport: %s", err)
+		}
+
+		if d.Get("read_cache").(bool) {
+			transport = internal.NewReadCache(transport)
+		}
+		transport = newQueryParamsTransport(transport)
+
+		session := &internal.Session{
+			AccountId:        creds.AccountId,
+			Endpoint:         creds.Endpoint,
+			DefaultOrgId:     d.Get("default_org_id").(string),
+			DefaultProjectId: d.Get("default_project_id").(string),
+			DefaultTags:      helpers.ExpandTags(d.Get("default_tags").(*schema.Set).List()),
+			AdoptExisting:    d.Get("adopt_existing").(bool),
+		}
+
+		// The clients are only built when a resource of their generation is used, and not at all
+		// when their api key isn't configured.
+		if creds.ApiKey != "" {
+			httpClient := getHttpClient(d, transport)
+			session.NewCDClient = func() (*cd.ApiClient, error) {
+				return getCDClient(creds, httpClient, version)
+			}
+		}
+
+		if creds.PlatformApiKey != "" {
+			httpClient := getHttpClient(d, transport)
+			session.NewPLClient = func() *nextgen.APIClient {
+				return getPLClient(creds, httpClient, version)
+			}
+			session.PLHTTPClient = httpClient
+		}
+
+		if d.Get("skip_credentials_validation").(bool) {
+			return session, nil
+		}
+
+		return session, validateCredentials(ctx, creds, getCredentialsCheckClient(transport), version)
 	}
 }
@@ -0,0 +1,34 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/harness/harness-go-sdk/harness/nextgen"
+	"github.com/harness/terraform-provider-harness/internal"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProvider(t *testing.T) {
+	require.NoError(t, Provider("dev")().InternalValidate())
+}
+
+func TestProviderNextGenAlias(t *testing.T) {
+	// The alias of the Next Gen overrides resource doesn't have the `harness_platform_` prefix but
+	// still only needs the platform api key.
+	r := Provider("dev")().ResourcesMap["harness_environment_service_overrides"]
+	require.Contains(t, r.Schema, "adopt_existing")
+
+	plOnly := &internal.Session{NewPLClient: func() *nextgen.APIClient { return &nextgen.APIClient{} }}
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"identifier": "test",
+		"org_id":     "org",
+		"project_id": "project",
+		"env_id":     "env",
+		"service_id": "service",
+		"yaml":       "serviceOverrides:\n  environmentRef: env\n  serviceRef: service\n",
+	})
+	_, err := r.Diff(context.Background(), nil, config, plOnly)
+	require.NoError(t, err)
+}
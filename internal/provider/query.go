@@ -0,0 +1,34 @@
+package provider
+
+import (
+	"net/http"
+
+	"github.com/harness/terraform-provider-harness/internal"
+)
+
+// queryParamsTransport adds the query parameters recorded in the request context with
+// internal.WithQueryParams to the request URL.
+type queryParamsTransport struct {
+	transport http.RoundTripper
+}
+
+func newQueryParamsTransport(transport http.RoundTripper) http.RoundTripper {
+	return &queryParamsTransport{transport: transport}
+}
+
+func (t *queryParamsTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	params := internal.GetQueryParams(req.Context())
+	if len(params) == 0 {
+		return t.transport.RoundTrip(req)
+	}
+
+	// Requests must not be modified by a RoundTripper so change a copy.
+	req = req.Clone(req.Context())
+	query := req.URL.Query()
+	for k, v := range params {
+		query[k] = v
+	}
+	req.URL.RawQuery = query.Encode()
+
+	return t.transport.RoundTrip(req)
+}
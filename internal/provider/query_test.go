@@ -0,0 +1,38 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/harness/terraform-provider-harness/internal"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueryParamsTransport(t *testing.T) {
+	var query url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query()
+	}))
+	defer server.Close()
+
+	c := &http.Client{Transport: newQueryParamsTransport(http.DefaultTransport)}
+
+	req, err := http.NewRequest(http.MethodDelete, server.URL+"/ng/api/connectors/test?accountIdentifier=test", nil)
+	require.NoError(t, err)
+	resp, err := c.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, url.Values{"accountIdentifier": {"test"}}, query)
+
+	ctx := internal.WithQueryParams(context.Background(), url.Values{"forceDelete": {"true"}})
+	req, err = http.NewRequestWithContext(ctx, http.MethodDelete, server.URL+"/ng/api/connectors/test?accountIdentifier=test", nil)
+	require.NoError(t, err)
+	resp, err = c.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, url.Values{"accountIdentifier": {"test"}, "forceDelete": {"true"}}, query)
+	require.Equal(t, "accountIdentifier=test", req.URL.RawQuery)
+}
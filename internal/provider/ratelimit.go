@@ -0,0 +1,36 @@
+package provider
+
+import (
+	"net/http"
+
+	"golang.org/x/time/rate"
+)
+
+// rateLimitedTransport is a token bucket limiter in front of the transport shared by every client.
+// It sits below the retry client, so each attempt takes a token but the time spent in backoff does
+// not, and a request waiting for a token gives up when its context is cancelled.
+type rateLimitedTransport struct {
+	limiter   *rate.Limiter
+	transport http.RoundTripper
+}
+
+// newRateLimitedTransport limits transport to requestsPerSecond, allowing a burst of the same size.
+// A limit of 0 returns transport unchanged.
+func newRateLimitedTransport(requestsPerSecond int, transport http.RoundTripper) http.RoundTripper {
+	if requestsPerSecond <= 0 {
+		return transport
+	}
+
+	return &rateLimitedTransport{
+		limiter:   rate.NewLimiter(rate.Limit(requestsPerSecond), requestsPerSecond),
+		transport: transport,
+	}
+}
+
+func (t *rateLimitedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := t.limiter.Wait(req.Context()); err != nil {
+		return nil, err
+	}
+
+	return t.transport.RoundTrip(req)
+}
@@ -0,0 +1,89 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimitedTransport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	d := schema.TestResourceDataRaw(t, Provider("dev")().Schema, map[string]interface{}{
+		"account_id":              "test",
+		"max_requests_per_second": 5,
+	})
+	transport, err := getTransport(d)
+	require.NoError(t, err)
+
+	// Both clients share the same transport and therefore the same limit.
+	clients := []*http.Client{getHttpClient(d, transport).StandardClient(), getHttpClient(d, transport).StandardClient()}
+
+	start := time.Now()
+	var wg sync.WaitGroup
+	for i := 0; i < 15; i++ {
+		wg.Add(1)
+		go func(c *http.Client) {
+			defer wg.Done()
+			resp, err := c.Get(server.URL)
+			require.NoError(t, err)
+			resp.Body.Close()
+		}(clients[i%2])
+	}
+	wg.Wait()
+
+	// The first 5 requests use the burst, the remaining 10 are spread over 2 seconds.
+	require.GreaterOrEqual(t, time.Since(start), 1900*time.Millisecond)
+}
+
+func TestRateLimitedTransportWithRetries(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+
+	d := schema.TestResourceDataRaw(t, Provider("dev")().Schema, map[string]interface{}{
+		"account_id":              "test",
+		"max_requests_per_second": 1,
+		"retry": []interface{}{map[string]interface{}{
+			"min_wait_seconds": 0,
+			"max_wait_seconds": 0,
+		}},
+	})
+	transport, err := getTransport(d)
+	require.NoError(t, err)
+
+	resp, err := getHttpClient(d, transport).StandardClient().Get(server.URL)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, int32(3), atomic.LoadInt32(&calls))
+}
+
+func TestRateLimitedTransportCancelled(t *testing.T) {
+	transport := newRateLimitedTransport(1, http.DefaultTransport)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+	resp, err := transport.RoundTrip(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err = transport.RoundTrip(req.WithContext(ctx))
+	require.Error(t, err)
+
+	require.Equal(t, http.DefaultTransport, newRateLimitedTransport(0, http.DefaultTransport))
+}
@@ -0,0 +1,190 @@
+package provider
+
+import (
+	"context"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/go-retryablehttp"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	defaultRetryMax     = 10
+	defaultRetryWaitMin = 1
+	defaultRetryWaitMax = 30
+)
+
+// retryPolicy controls how the http client used by the CD and NG clients retries failed requests.
+type retryPolicy struct {
+	MaxRetries      int
+	WaitMin         time.Duration
+	WaitMax         time.Duration
+	Jitter          bool
+	StatusCodes     map[int]bool
+	HonorRetryAfter bool
+}
+
+func getRetrySchema() *schema.Schema {
+	return &schema.Schema{
+		Description: "Retry policy applied to every request made to the Harness API.",
+		Type:        schema.TypeList,
+		Optional:    true,
+		MaxItems:    1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"max_retries": {
+					Description:  "Maximum number of times a failed request is retried.",
+					Type:         schema.TypeInt,
+					Optional:     true,
+					Default:      defaultRetryMax,
+					ValidateFunc: validation.IntAtLeast(0),
+				},
+				"min_wait_seconds": {
+					Description:  "Minimum time to wait between retries, in seconds.",
+					Type:         schema.TypeInt,
+					Optional:     true,
+					Default:      defaultRetryWaitMin,
+					ValidateFunc: validation.IntAtLeast(0),
+				},
+				"max_wait_seconds": {
+					Description:  "Maximum time to wait between retries, in seconds.",
+					Type:         schema.TypeInt,
+					Optional:     true,
+					Default:      defaultRetryWaitMax,
+					ValidateFunc: validation.IntAtLeast(0),
+				},
+				"jitter": {
+					Description: "Randomize the wait between retries to avoid many requests retrying in lockstep.",
+					Type:        schema.TypeBool,
+					Optional:    true,
+					Default:     false,
+				},
+				"retryable_status_codes": {
+					Description: "HTTP status codes that should be retried. When not set, 429 and 5xx responses (except 501) are retried.",
+					Type:        schema.TypeSet,
+					Optional:    true,
+					Elem: &schema.Schema{
+						Type:         schema.TypeInt,
+						ValidateFunc: validation.IntBetween(100, 599),
+					},
+				},
+				"honor_retry_after": {
+					Description: "Wait for the duration given in the `Retry-After` header of 429 and 503 responses, up to `max_wait_seconds`.",
+					Type:        schema.TypeBool,
+					Optional:    true,
+					Default:     true,
+				},
+			},
+		},
+	}
+}
+
+func defaultRetryPolicy() *retryPolicy {
+	return &retryPolicy{
+		MaxRetries:      defaultRetryMax,
+		WaitMin:         defaultRetryWaitMin * time.Second,
+		WaitMax:         defaultRetryWaitMax * time.Second,
+		HonorRetryAfter: true,
+	}
+}
+
+func expandRetryPolicy(l []interface{}) *retryPolicy {
+	policy := defaultRetryPolicy()
+
+	if len(l) == 0 || l[0] == nil {
+		return policy
+	}
+
+	config := l[0].(map[string]interface{})
+	policy.MaxRetries = config["max_retries"].(int)
+	policy.WaitMin = time.Duration(config["min_wait_seconds"].(int)) * time.Second
+	policy.WaitMax = time.Duration(config["max_wait_seconds"].(int)) * time.Second
+	policy.Jitter = config["jitter"].(bool)
+	policy.HonorRetryAfter = config["honor_retry_after"].(bool)
+
+	if codes := config["retryable_status_codes"].(*schema.Set).List(); len(codes) > 0 {
+		policy.StatusCodes = map[int]bool{}
+		for _, code := range codes {
+			policy.StatusCodes[code.(int)] = true
+		}
+	}
+
+	return policy
+}
+
+// apply configures the retryable client to use this policy.
+func (p *retryPolicy) apply(c *retryablehttp.Client) {
+	c.RetryMax = p.MaxRetries
+	c.RetryWaitMin = p.WaitMin
+	c.RetryWaitMax = p.WaitMax
+	c.CheckRetry = p.checkRetry
+	c.Backoff = p.backoff
+}
+
+func (p *retryPolicy) checkRetry(ctx context.Context, resp *http.Response, err error) (bool, error) {
+	// Transport errors and the default status handling are delegated to the library.
+	if err != nil || len(p.StatusCodes) == 0 {
+		return retryablehttp.DefaultRetryPolicy(ctx, resp, err)
+	}
+
+	if ctx.Err() != nil {
+		return false, ctx.Err()
+	}
+
+	return p.StatusCodes[resp.StatusCode], nil
+}
+
+func (p *retryPolicy) backoff(min, max time.Duration, attemptNum int, resp *http.Response) time.Duration {
+	if p.HonorRetryAfter {
+		// A long Retry-After would otherwise stall the apply for as long as the server asks.
+		if wait, ok := retryAfter(resp); ok {
+			if wait > max {
+				return max
+			}
+			return wait
+		}
+	}
+
+	wait := retryablehttp.DefaultBackoff(min, max, attemptNum, nil)
+
+	if p.Jitter && wait > min {
+		wait = min + time.Duration(rand.Int63n(int64(wait-min)+1))
+	}
+
+	return wait
+}
+
+// retryAfter reads the Retry-After header from 429 and 503 responses. Both the
+// delay-seconds and the http-date forms are supported.
+func retryAfter(resp *http.Response) (time.Duration, bool) {
+	if resp == nil {
+		return 0, false
+	}
+
+	if resp.StatusCode != http.StatusTooManyRequests && resp.StatusCode != http.StatusServiceUnavailable {
+		return 0, false
+	}
+
+	header := resp.Header.Get("Retry-After")
+	if header == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.ParseInt(header, 10, 64); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(header); err == nil {
+		wait := time.Until(date)
+		if wait < 0 {
+			wait = 0
+		}
+		return wait, true
+	}
+
+	return 0, false
+}
@@ -0,0 +1,139 @@
+package provider
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-retryablehttp"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/require"
+)
+
+// newStatusServer returns a server that responds with the given status codes in order,
+// falling back to 200 once they have all been used.
+func newStatusServer(t *testing.T, headers map[string]string, statuses ...int) (*httptest.Server, *int32) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := int(atomic.AddInt32(&calls, 1))
+		for k, v := range headers {
+			w.Header().Set(k, v)
+		}
+		if n <= len(statuses) {
+			w.WriteHeader(statuses[n-1])
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(server.Close)
+	return server, &calls
+}
+
+func newTestRetryClient(policy *retryPolicy) *retryablehttp.Client {
+	c := retryablehttp.NewClient()
+	c.Logger = nil
+	policy.apply(c)
+	return c
+}
+
+func testRetryPolicy() *retryPolicy {
+	policy := defaultRetryPolicy()
+	policy.WaitMin = time.Millisecond
+	policy.WaitMax = 5 * time.Millisecond
+	return policy
+}
+
+func TestRetryPolicyHonorsRetryAfter(t *testing.T) {
+	server, calls := newStatusServer(t, map[string]string{"Retry-After": "0"}, http.StatusTooManyRequests, http.StatusTooManyRequests)
+
+	resp, err := newTestRetryClient(testRetryPolicy()).Get(server.URL)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, int32(3), atomic.LoadInt32(calls))
+}
+
+func TestRetryPolicyMaxRetries(t *testing.T) {
+	server, calls := newStatusServer(t, nil, http.StatusBadGateway, http.StatusBadGateway, http.StatusBadGateway, http.StatusBadGateway)
+
+	policy := testRetryPolicy()
+	policy.MaxRetries = 2
+
+	_, err := newTestRetryClient(policy).Get(server.URL)
+	require.Error(t, err)
+	require.Equal(t, int32(3), atomic.LoadInt32(calls))
+}
+
+func TestRetryPolicyStatusCodes(t *testing.T) {
+	policy := testRetryPolicy()
+	policy.StatusCodes = map[int]bool{http.StatusConflict: true}
+
+	server, calls := newStatusServer(t, nil, http.StatusConflict)
+	resp, err := newTestRetryClient(policy).Get(server.URL)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, int32(2), atomic.LoadInt32(calls))
+
+	server, calls = newStatusServer(t, nil, http.StatusInternalServerError)
+	resp, err = newTestRetryClient(policy).Get(server.URL)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+	require.Equal(t, int32(1), atomic.LoadInt32(calls))
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	policy := testRetryPolicy()
+	policy.WaitMin = time.Second
+	policy.WaitMax = 10 * time.Second
+
+	resp := &http.Response{StatusCode: http.StatusTooManyRequests, Header: http.Header{}}
+	resp.Header.Set("Retry-After", "7")
+	require.Equal(t, 7*time.Second, policy.backoff(policy.WaitMin, policy.WaitMax, 0, resp))
+
+	// Retry-After is capped by max_wait_seconds.
+	resp.Header.Set("Retry-After", "3600")
+	require.Equal(t, 10*time.Second, policy.backoff(policy.WaitMin, policy.WaitMax, 0, resp))
+
+	resp.Header.Set("Retry-After", time.Now().Add(-time.Minute).UTC().Format(http.TimeFormat))
+	require.Equal(t, time.Duration(0), policy.backoff(policy.WaitMin, policy.WaitMax, 0, resp))
+
+	policy.HonorRetryAfter = false
+	require.Equal(t, 4*time.Second, policy.backoff(policy.WaitMin, policy.WaitMax, 2, resp))
+	require.Equal(t, 10*time.Second, policy.backoff(policy.WaitMin, policy.WaitMax, 8, resp))
+
+	policy.Jitter = true
+	for i := 0; i < 20; i++ {
+		wait := policy.backoff(policy.WaitMin, policy.WaitMax, 3, nil)
+		require.GreaterOrEqual(t, wait, policy.WaitMin)
+		require.LessOrEqual(t, wait, 8*time.Second)
+	}
+}
+
+func TestGetHttpClientRetryConfig(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, Provider("dev")().Schema, map[string]interface{}{
+		"account_id": "test",
+		"retry": []interface{}{
+			map[string]interface{}{
+				"max_retries":            3,
+				"min_wait_seconds":       2,
+				"max_wait_seconds":       20,
+				"retryable_status_codes": []interface{}{429, 503},
+			},
+		},
+	})
+
+	c := getHttpClient(d, http.DefaultTransport)
+	require.Equal(t, 3, c.RetryMax)
+	require.Equal(t, 2*time.Second, c.RetryWaitMin)
+	require.Equal(t, 20*time.Second, c.RetryWaitMax)
+
+	d = schema.TestResourceDataRaw(t, Provider("dev")().Schema, map[string]interface{}{
+		"account_id": "test",
+	})
+
+	c = getHttpClient(d, http.DefaultTransport)
+	require.Equal(t, defaultRetryMax, c.RetryMax)
+	require.Equal(t, defaultRetryWaitMin*time.Second, c.RetryWaitMin)
+	require.Equal(t, defaultRetryWaitMax*time.Second, c.RetryWaitMax)
+}
@@ -0,0 +1,142 @@
+package provider
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/go-cleanhttp"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func getTLSSchema() *schema.Schema {
+	return &schema.Schema{
+		Description: "TLS settings used when connecting to the Harness API, e.g. for self-managed installations using a private certificate authority.",
+		Type:        schema.TypeList,
+		Optional:    true,
+		MaxItems:    1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"ca_file": {
+					Description:   "Path to a PEM encoded CA bundle used to verify the server certificate. The bundle is added to the system certificate pool.",
+					Type:          schema.TypeString,
+					Optional:      true,
+					ConflictsWith: []string{"tls.0.ca_pem"},
+				},
+				"ca_pem": {
+					Description:   "PEM encoded CA bundle used to verify the server certificate. The bundle is added to the system certificate pool.",
+					Type:          schema.TypeString,
+					Optional:      true,
+					ConflictsWith: []string{"tls.0.ca_file"},
+				},
+				"client_cert": {
+					Description:  "PEM encoded client certificate, or the path to one, used for mutual TLS.",
+					Type:         schema.TypeString,
+					Optional:     true,
+					RequiredWith: []string{"tls.0.client_key"},
+				},
+				"client_key": {
+					Description:  "PEM encoded private key for `client_cert`, or the path to one.",
+					Type:         schema.TypeString,
+					Optional:     true,
+					Sensitive:    true,
+					RequiredWith: []string{"tls.0.client_cert"},
+				},
+				"insecure_skip_verify": {
+					Description: "Skip verification of the server certificate. This should only be used for testing.",
+					Type:        schema.TypeBool,
+					Optional:    true,
+					Default:     false,
+				},
+			},
+		},
+	}
+}
+
+// getPooledTransport builds the base transport from the `tls` and `proxy_url` settings.
+func getPooledTransport(d *schema.ResourceData) (*http.Transport, error) {
+	transport := cleanhttp.DefaultPooledTransport()
+
+	if proxyUrl := d.Get("proxy_url").(string); proxyUrl != "" {
+		u, err := url.Parse(proxyUrl)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxy_url: %s", err)
+		}
+		transport.Proxy = http.ProxyURL(u)
+	}
+
+	tlsConfig, err := expandTLSConfig(d.Get("tls").([]interface{}))
+	if err != nil {
+		return nil, err
+	}
+	transport.TLSClientConfig = tlsConfig
+
+	return transport, nil
+}
+
+func expandTLSConfig(l []interface{}) (*tls.Config, error) {
+	if len(l) == 0 || l[0] == nil {
+		return nil, nil
+	}
+
+	opts := l[0].(map[string]interface{})
+	config := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: opts["insecure_skip_verify"].(bool),
+	}
+
+	caPem := []byte(opts["ca_pem"].(string))
+	if caFile := opts["ca_file"].(string); caFile != "" {
+		data, err := ioutil.ReadFile(caFile)
+		if err != nil {
+			return nil, fmt.Errorf("error reading tls ca_file: %s", err)
+		}
+		caPem = data
+	}
+
+	if len(caPem) > 0 {
+		pool, err := x509.SystemCertPool()
+		if err != nil || pool == nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(caPem) {
+			return nil, fmt.Errorf("no valid certificates found in tls CA bundle")
+		}
+		config.RootCAs = pool
+	}
+
+	if clientCert := opts["client_cert"].(string); clientCert != "" {
+		certPem, err := readPEM("client_cert", clientCert)
+		if err != nil {
+			return nil, err
+		}
+		keyPem, err := readPEM("client_key", opts["client_key"].(string))
+		if err != nil {
+			return nil, err
+		}
+		cert, err := tls.X509KeyPair(certPem, keyPem)
+		if err != nil {
+			return nil, fmt.Errorf("error loading tls client certificate: %s", err)
+		}
+		config.Certificates = []tls.Certificate{cert}
+	}
+
+	return config, nil
+}
+
+// readPEM returns value itself when it is PEM encoded, otherwise it reads the file it points to.
+func readPEM(attr string, value string) ([]byte, error) {
+	if strings.Contains(value, "-----BEGIN") {
+		return []byte(value), nil
+	}
+
+	data, err := ioutil.ReadFile(value)
+	if err != nil {
+		return nil, fmt.Errorf("error reading tls %s: %s", attr, err)
+	}
+	return data, nil
+}
@@ -0,0 +1,147 @@
+package provider
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/require"
+)
+
+func newTLSTestClient(t *testing.T, config map[string]interface{}) *http.Client {
+	config["account_id"] = "test"
+	d := schema.TestResourceDataRaw(t, Provider("dev")().Schema, config)
+
+	transport, err := getTransport(d)
+	require.NoError(t, err)
+	c := getHttpClient(d, transport)
+	c.RetryMax = 0
+	return c.StandardClient()
+}
+
+func certToPEM(cert *x509.Certificate) string {
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw}))
+}
+
+// newClientCertificate returns a self signed client certificate and its key, both PEM encoded.
+func newClientCertificate(t *testing.T) (*x509.Certificate, string, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "terraform"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	return cert, certToPEM(cert), string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}))
+}
+
+func TestTLSCustomCA(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	_, err := newTLSTestClient(t, map[string]interface{}{}).Get(server.URL)
+	require.Error(t, err)
+
+	resp, err := newTLSTestClient(t, map[string]interface{}{
+		"tls": []interface{}{map[string]interface{}{
+			"ca_pem": certToPEM(server.Certificate()),
+		}},
+	}).Get(server.URL)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	caFile := writeCredentialsFile(t, "ca.pem", certToPEM(server.Certificate()))
+	resp, err = newTLSTestClient(t, map[string]interface{}{
+		"tls": []interface{}{map[string]interface{}{
+			"ca_file": caFile,
+		}},
+	}).Get(server.URL)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp, err = newTLSTestClient(t, map[string]interface{}{
+		"tls": []interface{}{map[string]interface{}{
+			"insecure_skip_verify": true,
+		}},
+	}).Get(server.URL)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+func TestTLSClientCertificate(t *testing.T) {
+	clientCert, certPem, keyPem := newClientCertificate(t)
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	clientCAs := x509.NewCertPool()
+	clientCAs.AddCert(clientCert)
+	server.TLS = &tls.Config{ClientAuth: tls.RequireAndVerifyClientCert, ClientCAs: clientCAs}
+	server.StartTLS()
+	defer server.Close()
+
+	_, err := newTLSTestClient(t, map[string]interface{}{
+		"tls": []interface{}{map[string]interface{}{
+			"ca_pem": certToPEM(server.Certificate()),
+		}},
+	}).Get(server.URL)
+	require.Error(t, err)
+
+	resp, err := newTLSTestClient(t, map[string]interface{}{
+		"tls": []interface{}{map[string]interface{}{
+			"ca_pem":      certToPEM(server.Certificate()),
+			"client_cert": certPem,
+			"client_key":  writeCredentialsFile(t, "client.key", keyPem),
+		}},
+	}).Get(server.URL)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+func TestTLSInvalidConfig(t *testing.T) {
+	for _, opts := range []map[string]interface{}{
+		{"ca_pem": "not a certificate"},
+		{"ca_file": "/does/not/exist"},
+		{"client_cert": "/does/not/exist", "client_key": "/does/not/exist"},
+	} {
+		d := schema.TestResourceDataRaw(t, Provider("dev")().Schema, map[string]interface{}{
+			"tls": []interface{}{opts},
+		})
+		_, err := getTransport(d)
+		require.Error(t, err)
+	}
+}
+
+func TestProxyUrl(t *testing.T) {
+	var proxied string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied = r.URL.String()
+	}))
+	defer proxy.Close()
+
+	resp, err := newTLSTestClient(t, map[string]interface{}{
+		"proxy_url": proxy.URL,
+	}).Get("http://harness.example.com/gateway/ng/api/user/currentUser")
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, "http://harness.example.com/gateway/ng/api/user/currentUser", proxied)
+}
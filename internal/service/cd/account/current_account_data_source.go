@@ -36,7 +36,7 @@ func DataSourceCurrentAccountConnector() *schema.Resource {
 }
 
 func dataSourceGitConnectorCurrentAccount(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	c := meta.(*internal.Session).CDClient
+	c := meta.(*internal.Session).GetCDClient()
 
 	d.SetId(c.Configuration.AccountId)
 	d.Set("account_id", c.Configuration.AccountId)
@@ -65,7 +65,7 @@ func ResourceApplication() *schema.Resource {
 }
 
 func resourceApplicationCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	c := meta.(*internal.Session).CDClient
+	c := meta.(*internal.Session).GetCDClient()
 
 	input := &graphql.CreateApplicationInput{
 		Name:                      d.Get("name").(string),
@@ -93,7 +93,7 @@ func resourceApplicationCreate(ctx context.Context, d *schema.ResourceData, meta
 }
 
 func resourceApplicationRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	c := meta.(*internal.Session).CDClient
+	c := meta.(*internal.Session).GetCDClient()
 
 	appId := d.Get("id").(string)
 
@@ -142,7 +142,7 @@ func applicationRead(d *schema.ResourceData, app *graphql.Application) {
 }
 
 func resourceApplicationUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	c := meta.(*internal.Session).CDClient
+	c := meta.(*internal.Session).GetCDClient()
 
 	input := &graphql.UpdateApplicationInput{
 		ApplicationId:             d.Id(),
@@ -183,7 +183,7 @@ func resourceApplicationUpdate(ctx context.Context, d *schema.ResourceData, meta
 }
 
 func resourceApplicationDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	c := meta.(*internal.Session).CDClient
+	c := meta.(*internal.Session).GetCDClient()
 
 	if err := c.ApplicationClient.DeleteApplication(d.Id()); err != nil {
 		return diag.FromErr(err)
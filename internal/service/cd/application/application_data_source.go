@@ -54,7 +54,7 @@ func DataSourceApplication() *schema.Resource {
 
 func dataSourceApplicationRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	// use the meta value to retrieve your client from the provider configure method
-	c := meta.(*internal.Session).CDClient
+	c := meta.(*internal.Session).GetCDClient()
 
 	var app *graphql.Application
 	var err error
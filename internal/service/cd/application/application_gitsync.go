@@ -58,7 +58,7 @@ func ResourceApplicationGitSync() *schema.Resource {
 }
 
 func resourceApplicationGitSyncCreateOrUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	c := meta.(*internal.Session).CDClient
+	c := meta.(*internal.Session).GetCDClient()
 
 	input := &graphql.UpdateApplicationGitSyncConfigInput{}
 
@@ -109,7 +109,7 @@ func readGitSyncConfig(d *schema.ResourceData, config *graphql.GitSyncConfig) {
 }
 
 func resourceApplicationGitSyncRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	c := meta.(*internal.Session).CDClient
+	c := meta.(*internal.Session).GetCDClient()
 
 	appId := d.Get("app_id").(string)
 
@@ -131,7 +131,7 @@ func resourceApplicationGitSyncRead(ctx context.Context, d *schema.ResourceData,
 }
 
 func resourceApplicationGitSyncDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	c := meta.(*internal.Session).CDClient
+	c := meta.(*internal.Session).GetCDClient()
 
 	if err := c.ApplicationClient.RemoveGitSyncConfig(d.Get("app_id").(string)); err != nil {
 		return diag.FromErr(err)
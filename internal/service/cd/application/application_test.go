@@ -72,7 +72,7 @@ func TestAccResourceApplication_DeleteUnderlyingResource(t *testing.T) {
 			{
 				PreConfig: func() {
 					acctest.TestAccConfigureProvider()
-					c := acctest.TestAccProvider.Meta().(*internal.Session).CDClient
+					c := acctest.TestAccProvider.Meta().(*internal.Session).GetCDClient()
 					app, err := c.ApplicationClient.GetApplicationByName(expectedName)
 					require.NoError(t, err)
 					require.NotNil(t, app)
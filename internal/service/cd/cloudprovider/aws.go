@@ -98,7 +98,7 @@ func ResourceCloudProviderAws() *schema.Resource {
 }
 
 func resourceCloudProviderAwsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	c := meta.(*internal.Session).CDClient
+	c := meta.(*internal.Session).GetCDClient()
 
 	cp := &cac.AwsCloudProvider{}
 	if err := c.ConfigAsCodeClient.GetCloudProviderById(d.Id(), cp); err != nil {
@@ -113,7 +113,7 @@ func resourceCloudProviderAwsRead(ctx context.Context, d *schema.ResourceData, m
 }
 
 func resourceCloudProviderAwsCreateOrUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	c := meta.(*internal.Session).CDClient
+	c := meta.(*internal.Session).GetCDClient()
 
 	var input *cac.AwsCloudProvider
 	var err error
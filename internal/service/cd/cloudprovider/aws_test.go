@@ -63,11 +63,11 @@ func TestAccResourceAwsCloudProvider_DeleteUnderlyingResourcez(t *testing.T) {
 				PreConfig: func() {
 					acctest.TestAccConfigureProvider()
 					c := acctest.TestAccProvider.Meta().(*internal.Session)
-					cp, err := c.CDClient.CloudProviderClient.GetAwsCloudProviderByName(name)
+					cp, err := c.GetCDClient().CloudProviderClient.GetAwsCloudProviderByName(name)
 					require.NoError(t, err)
 					require.NotNil(t, cp)
 
-					err = c.CDClient.CloudProviderClient.DeleteCloudProvider(cp.Id)
+					err = c.GetCDClient().CloudProviderClient.DeleteCloudProvider(cp.Id)
 					require.NoError(t, err)
 				},
 				Config:             acctest.TestAccResourceAwsCloudProvider(name),
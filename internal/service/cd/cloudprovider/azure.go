@@ -64,7 +64,7 @@ func ResourceCloudProviderAzure() *schema.Resource {
 }
 
 func resourceCloudProviderAzureRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	c := meta.(*internal.Session).CDClient
+	c := meta.(*internal.Session).GetCDClient()
 
 	cp := &cac.AzureCloudProvider{}
 	if err := c.ConfigAsCodeClient.GetCloudProviderById(d.Id(), cp); err != nil {
@@ -79,7 +79,7 @@ func resourceCloudProviderAzureRead(ctx context.Context, d *schema.ResourceData,
 }
 
 func resourceCloudProviderAzureCreateOrUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	c := meta.(*internal.Session).CDClient
+	c := meta.(*internal.Session).GetCDClient()
 
 	var input *cac.AzureCloudProvider
 	var err error
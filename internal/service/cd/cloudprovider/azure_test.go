@@ -70,7 +70,7 @@ func TestAccResourceAzureCloudProviderConnector_DeleteUnderlyingResource(t *test
 			{
 				PreConfig: func() {
 					acctest.TestAccConfigureProvider()
-					c := acctest.TestAccProvider.Meta().(*internal.Session).CDClient
+					c := acctest.TestAccProvider.Meta().(*internal.Session).GetCDClient()
 					cp, err := c.CloudProviderClient.GetAzureCloudProviderByName(name)
 					require.NoError(t, err)
 					require.NotNil(t, cp)
@@ -29,7 +29,7 @@ func commonCloudProviderSchema() map[string]*schema.Schema {
 
 func resourceCloudProviderDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	log.Printf("[DEBUG] Deleting cloud provider %s", d.Get("name"))
-	c := meta.(*internal.Session).CDClient
+	c := meta.(*internal.Session).GetCDClient()
 
 	id := d.Get("id").(string)
 	err := c.CloudProviderClient.DeleteCloudProvider(id)
@@ -32,7 +32,7 @@ func ResourceCloudProviderDataCenter() *schema.Resource {
 }
 
 func resourceCloudProviderDataCenterRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	c := meta.(*internal.Session).CDClient
+	c := meta.(*internal.Session).GetCDClient()
 
 	cp := &cac.PhysicalDatacenterCloudProvider{}
 	if err := c.ConfigAsCodeClient.GetCloudProviderById(d.Id(), cp); err != nil {
@@ -61,7 +61,7 @@ func readCloudProviderDataCenter(c *cd.ApiClient, d *schema.ResourceData, cp *ca
 }
 
 func resourceCloudProviderDataCenterCreateOrUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	c := meta.(*internal.Session).CDClient
+	c := meta.(*internal.Session).GetCDClient()
 
 	var input *cac.PhysicalDatacenterCloudProvider
 	var err error
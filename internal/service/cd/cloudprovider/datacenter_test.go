@@ -70,7 +70,7 @@ func TestAccResourceDataCenterCloudProviderConnector_DeleteUnderlyingResource(t
 			{
 				PreConfig: func() {
 					acctest.TestAccConfigureProvider()
-					c := acctest.TestAccProvider.Meta().(*internal.Session).CDClient
+					c := acctest.TestAccProvider.Meta().(*internal.Session).GetCDClient()
 					cp, err := c.CloudProviderClient.GetPhysicalDatacenterCloudProviderByName(name)
 					require.NoError(t, err)
 					require.NotNil(t, cp)
@@ -122,7 +122,7 @@ func testAccGetCloudProvider(resourceName string, state *terraform.State, respOb
 	c := acctest.TestAccGetApiClientFromProvider()
 	name := r.Primary.Attributes["name"]
 
-	err := c.CDClient.ConfigAsCodeClient.GetCloudProviderByName(name, respObj)
+	err := c.GetCDClient().ConfigAsCodeClient.GetCloudProviderByName(name, respObj)
 	if err != nil {
 		return err
 	}
@@ -58,7 +58,7 @@ func ResourceCloudProviderGcp() *schema.Resource {
 }
 
 func resourceCloudProviderGcpRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	c := meta.(*internal.Session).CDClient
+	c := meta.(*internal.Session).GetCDClient()
 
 	cp := &cac.GcpCloudProvider{}
 	if err := c.ConfigAsCodeClient.GetCloudProviderById(d.Id(), cp); err != nil {
@@ -92,7 +92,7 @@ func readCloudProviderGcp(c *cd.ApiClient, d *schema.ResourceData, cp *cac.GcpCl
 }
 
 func resourceCloudProviderGcpCreateOrUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	c := meta.(*internal.Session).CDClient
+	c := meta.(*internal.Session).GetCDClient()
 
 	var input *cac.GcpCloudProvider
 	var err error
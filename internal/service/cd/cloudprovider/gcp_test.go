@@ -69,7 +69,7 @@ func TestAccResourceGcpCloudProviderConnector_DeleteUnderlyingResource(t *testin
 			{
 				PreConfig: func() {
 					acctest.TestAccConfigureProvider()
-					c := acctest.TestAccProvider.Meta().(*internal.Session).CDClient
+					c := acctest.TestAccProvider.Meta().(*internal.Session).GetCDClient()
 					cp, err := c.CloudProviderClient.GetGcpCloudProviderByName(name)
 					require.NoError(t, err)
 					require.NotNil(t, cp)
@@ -250,7 +250,7 @@ func ResourceCloudProviderK8s() *schema.Resource {
 }
 
 func resourceCloudProviderK8sRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	c := meta.(*internal.Session).CDClient
+	c := meta.(*internal.Session).GetCDClient()
 
 	cp := &cac.KubernetesCloudProvider{}
 	id := d.Id()
@@ -284,7 +284,7 @@ func readCloudProviderK8s(c *cd.ApiClient, d *schema.ResourceData, cp *cac.Kuber
 }
 
 func resourceCloudProviderK8sCreateOrUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	c := meta.(*internal.Session).CDClient
+	c := meta.(*internal.Session).GetCDClient()
 
 	var input *cac.KubernetesCloudProvider
 	var err error
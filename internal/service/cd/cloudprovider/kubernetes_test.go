@@ -170,7 +170,7 @@ func TestAccResourceK8sCloudProviderConnector_DeleteUnderlyingResource(t *testin
 			{
 				PreConfig: func() {
 					acctest.TestAccConfigureProvider()
-					c := acctest.TestAccProvider.Meta().(*internal.Session).CDClient
+					c := acctest.TestAccProvider.Meta().(*internal.Session).GetCDClient()
 					cp, err := c.CloudProviderClient.GetKubernetesCloudProviderByName(name)
 					require.NoError(t, err)
 					require.NotNil(t, cp)
@@ -49,7 +49,7 @@ func ResourceCloudProviderSpot() *schema.Resource {
 }
 
 func resourceCloudProviderSpotRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	c := meta.(*internal.Session).CDClient
+	c := meta.(*internal.Session).GetCDClient()
 
 	cp := &cac.SpotInstCloudProvider{}
 	if err := c.ConfigAsCodeClient.GetCloudProviderById(d.Id(), cp); err != nil {
@@ -76,7 +76,7 @@ func readCloudProviderSpot(c *cd.ApiClient, d *schema.ResourceData, cp *cac.Spot
 }
 
 func resourceCloudProviderSpotCreateOrUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	c := meta.(*internal.Session).CDClient
+	c := meta.(*internal.Session).GetCDClient()
 
 	var input *cac.SpotInstCloudProvider
 	var err error
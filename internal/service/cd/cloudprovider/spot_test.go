@@ -63,7 +63,7 @@ func TestAccResourceSpotCloudProviderConnector_DeleteUnderlyingResource(t *testi
 			{
 				PreConfig: func() {
 					acctest.TestAccConfigureProvider()
-					c := acctest.TestAccProvider.Meta().(*internal.Session).CDClient
+					c := acctest.TestAccProvider.Meta().(*internal.Session).GetCDClient()
 					cp, err := c.CloudProviderClient.GetSpotInstCloudProviderByName(name)
 					require.NoError(t, err)
 					require.NotNil(t, cp)
@@ -67,7 +67,7 @@ func ResourceCloudProviderTanzu() *schema.Resource {
 }
 
 func resourceCloudProviderTanzuRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	c := meta.(*internal.Session).CDClient
+	c := meta.(*internal.Session).GetCDClient()
 
 	cp := &cac.PcfCloudProvider{}
 	if err := c.ConfigAsCodeClient.GetCloudProviderById(d.Id(), cp); err != nil {
@@ -99,7 +99,7 @@ func readCloudProviderTanzu(c *cd.ApiClient, d *schema.ResourceData, cp *cac.Pcf
 }
 
 func resourceCloudProviderTanzuCreateOrUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	c := meta.(*internal.Session).CDClient
+	c := meta.(*internal.Session).GetCDClient()
 
 	var input *cac.PcfCloudProvider
 	var err error
@@ -64,7 +64,7 @@ func TestAccResourceTanzuCloudProviderConnector_DeleteUnderlyingResource(t *test
 			{
 				PreConfig: func() {
 					acctest.TestAccConfigureProvider()
-					c := acctest.TestAccProvider.Meta().(*internal.Session).CDClient
+					c := acctest.TestAccProvider.Meta().(*internal.Session).GetCDClient()
 					cp, err := c.CloudProviderClient.GetPcfCloudProviderByName(name)
 					require.NoError(t, err)
 					require.NotNil(t, cp)
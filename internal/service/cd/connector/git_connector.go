@@ -126,7 +126,7 @@ func ResourceGitConnector() *schema.Resource {
 }
 
 func resourceGitConnectorRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	c := meta.(*internal.Session).CDClient
+	c := meta.(*internal.Session).GetCDClient()
 
 	connId := d.Get("id").(string)
 
@@ -203,7 +203,7 @@ func setGitConnectorConfig(d *schema.ResourceData, connInput *graphql.GitConnect
 }
 
 func resourceGitConnectorCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	c := meta.(*internal.Session).CDClient
+	c := meta.(*internal.Session).GetCDClient()
 
 	connInput := &graphql.GitConnectorInput{}
 	err := setGitConnectorConfig(d, connInput, false)
@@ -220,7 +220,7 @@ func resourceGitConnectorCreate(ctx context.Context, d *schema.ResourceData, met
 }
 
 func resourceGitConnectorUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	c := meta.(*internal.Session).CDClient
+	c := meta.(*internal.Session).GetCDClient()
 
 	id := d.Get("id").(string)
 
@@ -239,7 +239,7 @@ func resourceGitConnectorUpdate(ctx context.Context, d *schema.ResourceData, met
 }
 
 func resourceGitConnectorDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	c := meta.(*internal.Session).CDClient
+	c := meta.(*internal.Session).GetCDClient()
 
 	id := d.Get("id").(string)
 
@@ -115,7 +115,7 @@ func DataSourceGitConnector() *schema.Resource {
 }
 
 func dataSourceGitConnectorRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	c := meta.(*internal.Session).CDClient
+	c := meta.(*internal.Session).GetCDClient()
 
 	var conn *graphql.GitConnector
 	var err error
@@ -30,14 +30,14 @@ func testSweepGitConnectors(r string) error {
 
 	for hasMore {
 
-		connectors, _, err := c.CDClient.ConnectorClient.ListGitConnectors(limit, offset)
+		connectors, _, err := c.GetCDClient().ConnectorClient.ListGitConnectors(limit, offset)
 		if err != nil {
 			return err
 		}
 
 		for _, conn := range connectors {
 			if strings.HasPrefix(conn.Name, "test_") {
-				if err = c.CDClient.ConnectorClient.DeleteConnector(conn.Id); err != nil {
+				if err = c.GetCDClient().ConnectorClient.DeleteConnector(conn.Id); err != nil {
 					return err
 				}
 			}
@@ -107,11 +107,11 @@ func TestAccResourceGitConnector_DeleteUnderlyingResource(t *testing.T) {
 				PreConfig: func() {
 					acctest.TestAccConfigureProvider()
 					c := acctest.TestAccProvider.Meta().(*internal.Session)
-					conn, err := c.CDClient.ConnectorClient.GetGitConnectorByName(name)
+					conn, err := c.GetCDClient().ConnectorClient.GetGitConnectorByName(name)
 					require.NoError(t, err)
 					require.NotNil(t, conn)
 
-					err = c.CDClient.ConnectorClient.DeleteConnector(conn.Id)
+					err = c.GetCDClient().ConnectorClient.DeleteConnector(conn.Id)
 					require.NoError(t, err)
 				},
 				Config:             testAccResourceGitConnector(name, true, true, true),
@@ -127,7 +127,7 @@ func testAccGetGitConnector(resourceName string, state *terraform.State) (*graph
 	c := acctest.TestAccGetApiClientFromProvider()
 	id := r.Primary.ID
 
-	return c.CDClient.ConnectorClient.GetGitConnectorById(id)
+	return c.GetCDClient().ConnectorClient.GetGitConnectorById(id)
 }
 
 func testAccCheckGitConnectorExists(t *testing.T, resourceName string, connectorName string) resource.TestCheckFunc {
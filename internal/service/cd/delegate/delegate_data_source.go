@@ -90,7 +90,7 @@ func DataSourceDelegate() *schema.Resource {
 
 func dataSourceDelegateRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	// use the meta value to retrieve your client from the provider configure method
-	c := meta.(*internal.Session).CDClient
+	c := meta.(*internal.Session).GetCDClient()
 
 	id := d.Get("id").(string)
 	name := d.Get("name").(string)
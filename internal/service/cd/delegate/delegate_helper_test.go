@@ -33,7 +33,7 @@ func getDelegateTimeout() time.Duration {
 
 func createDelegateContainer(t *testing.T, name string, pullImage bool) *graphql.Delegate {
 	ctx := context.Background()
-	c := acctest.TestAccProvider.Meta().(*internal.Session).CDClient
+	c := acctest.TestAccProvider.Meta().(*internal.Session).GetCDClient()
 
 	cfg := &delegate.DockerDelegateConfig{
 		AccountId:     c.Configuration.AccountId,
@@ -55,7 +55,7 @@ func createDelegateContainer(t *testing.T, name string, pullImage bool) *graphql
 }
 
 func deleteDelegate(t *testing.T, name string) {
-	c := acctest.TestAccProvider.Meta().(*internal.Session).CDClient
+	c := acctest.TestAccProvider.Meta().(*internal.Session).GetCDClient()
 	delegate, err := c.DelegateClient.GetDelegateByName(name)
 	require.NoError(t, err, "Failed to get delegate: %s", err)
 	require.NotNil(t, delegate, "Delegate should not be nil")
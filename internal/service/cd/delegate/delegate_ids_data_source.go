@@ -46,7 +46,7 @@ func DataSourceDelegateIds() *schema.Resource {
 
 func dataSourceDelegateIdsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	// use the meta value to retrieve your client from the provider configure method
-	c := meta.(*internal.Session).CDClient
+	c := meta.(*internal.Session).GetCDClient()
 
 	name := d.Get("name").(string)
 	status := d.Get("status").(string)
@@ -2,9 +2,9 @@ package environment
 
 import (
 	"context"
-	"strings"
 
 	"github.com/harness/harness-go-sdk/harness/cd/cac"
+	"github.com/harness/terraform-provider-harness/helpers"
 	"github.com/harness/terraform-provider-harness/internal"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -79,21 +79,15 @@ func ResourceEnvironment() *schema.Resource {
 			},
 		},
 
-		Importer: &schema.ResourceImporter{
-			State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-				// <app_id>/<env_id>
-				parts := strings.Split(d.Id(), "/")
-				d.Set("app_id", parts[0])
-				d.SetId(parts[1])
-
-				return []*schema.ResourceData{d}, nil
-			},
-		},
+		Importer: (&helpers.ImportIdParser{
+			Formats:     []helpers.ImportIdFormat{{"app_id", "id"}},
+			IdAttribute: "id",
+		}).Importer(),
 	}
 }
 
 func resourceEnvironmentRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	c := meta.(*internal.Session).CDClient
+	c := meta.(*internal.Session).GetCDClient()
 
 	var env *cac.Environment
 	var err error
@@ -128,7 +122,7 @@ func readEnvironment(d *schema.ResourceData, env *cac.Environment) diag.Diagnost
 }
 
 func resourceEnvironmentCreateOrUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	c := meta.(*internal.Session).CDClient
+	c := meta.(*internal.Session).GetCDClient()
 
 	appId := d.Get("app_id").(string)
 	id := d.Get("id").(string)
@@ -208,7 +202,7 @@ func expandVariableOverrides(d []interface{}) []*cac.VariableOverride {
 }
 
 func resourceEnvironmentDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	c := meta.(*internal.Session).CDClient
+	c := meta.(*internal.Session).GetCDClient()
 
 	envName := d.Get("name").(string)
 	appId := d.Get("app_id").(string)
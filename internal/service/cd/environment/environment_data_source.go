@@ -94,12 +94,12 @@ func dataSourceEnvironmentRead(ctx context.Context, d *schema.ResourceData, meta
 	appId := d.Get("app_id").(string)
 
 	if id := d.Get("environment_id").(string); id != "" {
-		env, err = c.CDClient.ConfigAsCodeClient.GetEnvironmentById(appId, id)
+		env, err = c.GetCDClient().ConfigAsCodeClient.GetEnvironmentById(appId, id)
 		if err != nil {
 			return diag.FromErr(err)
 		}
 	} else if name := d.Get("name").(string); name != "" {
-		env, err = c.CDClient.ConfigAsCodeClient.GetEnvironmentByName(appId, name)
+		env, err = c.GetCDClient().ConfigAsCodeClient.GetEnvironmentByName(appId, name)
 		if err != nil {
 			return diag.FromErr(err)
 		}
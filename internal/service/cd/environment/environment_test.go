@@ -109,7 +109,7 @@ func TestAccResourceEnvironment_DeleteUnderlyingResource(t *testing.T) {
 			{
 				PreConfig: func() {
 					acctest.TestAccConfigureProvider()
-					c := acctest.TestAccProvider.Meta().(*internal.Session).CDClient
+					c := acctest.TestAccProvider.Meta().(*internal.Session).GetCDClient()
 					app, err := c.ApplicationClient.GetApplicationByName(name)
 					require.NoError(t, err)
 					require.NotNil(t, app)
@@ -131,7 +131,7 @@ func TestAccResourceEnvironment_DeleteUnderlyingResource(t *testing.T) {
 
 func testAccGetEnvironment(resourceName string, state *terraform.State) (*cac.Environment, error) {
 	r := acctest.TestAccGetResource(resourceName, state)
-	c := acctest.TestAccGetApiClientFromProvider().CDClient
+	c := acctest.TestAccGetApiClientFromProvider().GetCDClient()
 	svcId := r.Primary.ID
 	appId := r.Primary.Attributes["app_id"]
 
@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/harness/harness-go-sdk/harness/cd/cac"
+	"github.com/harness/terraform-provider-harness/helpers"
 	"github.com/harness/terraform-provider-harness/internal"
 	"github.com/harness/terraform-provider-harness/internal/utils"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -204,18 +205,10 @@ func ResourceInfraDefinition() *schema.Resource {
 				ExactlyOneOf:  infraDetailTypes,
 			},
 		},
-		Importer: &schema.ResourceImporter{
-			State: func(d *schema.ResourceData, i interface{}) ([]*schema.ResourceData, error) {
-				// <app_id>/<env_id>/<id>
-				parts := strings.Split(d.Id(), "/")
-
-				d.Set("app_id", parts[0])
-				d.Set("env_id", parts[1])
-				d.SetId(parts[2])
-
-				return []*schema.ResourceData{d}, nil
-			},
-		},
+		Importer: (&helpers.ImportIdParser{
+			Formats:     []helpers.ImportIdFormat{{"app_id", "env_id", "id"}},
+			IdAttribute: "id",
+		}).Importer(),
 	}
 }
 
@@ -227,7 +220,7 @@ func resourceInfraDefinitionRead(ctx context.Context, d *schema.ResourceData, me
 	envId := d.Get("env_id").(string)
 
 	log.Printf("[DEBUG] Terraform: Read infrastructure definition %s", id)
-	infraDef, err := c.CDClient.ConfigAsCodeClient.GetInfraDefinitionById(appId, envId, id)
+	infraDef, err := c.GetCDClient().ConfigAsCodeClient.GetInfraDefinitionById(appId, envId, id)
 	if err != nil {
 		return diag.FromErr(err)
 	} else if infraDef == nil {
@@ -249,7 +242,7 @@ func resourceInfraDefinitionDelete(ctx context.Context, d *schema.ResourceData,
 	envId := d.Get("env_id").(string)
 
 	log.Printf("[DEBUG] Terraform: Delete infrastructure definition %s", id)
-	err := c.CDClient.ConfigAsCodeClient.DeleteInfraDefinition(appId, envId, id)
+	err := c.GetCDClient().ConfigAsCodeClient.DeleteInfraDefinition(appId, envId, id)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -271,7 +264,7 @@ func resourceInfraDefinitionCreateOrUpdate(ctx context.Context, d *schema.Resour
 		appId := d.Get("app_id").(string)
 		envId := d.Get("env_id").(string)
 		log.Printf("[DEBUG] Terraform: Updating infrastructure definition %s", d.Get("name"))
-		input, err = c.CDClient.ConfigAsCodeClient.GetInfraDefinitionById(appId, envId, id)
+		input, err = c.GetCDClient().ConfigAsCodeClient.GetInfraDefinitionById(appId, envId, id)
 		if err != nil {
 			return diag.FromErr(err)
 		}
@@ -316,7 +309,7 @@ func resourceInfraDefinitionCreateOrUpdate(ctx context.Context, d *schema.Resour
 	expandTanzuConfiguration(d.Get("tanzu").([]interface{}), input)
 	expandAzureWebAppConfiguration(d.Get("azure_webapp").([]interface{}), input)
 
-	infraDef, err := c.CDClient.ConfigAsCodeClient.UpsertInfraDefinition(input)
+	infraDef, err := c.GetCDClient().ConfigAsCodeClient.UpsertInfraDefinition(input)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -79,7 +79,7 @@ func resourceEncryptedTextRead(ctx context.Context, d *schema.ResourceData, meta
 
 	secretId := d.Get("id").(string)
 
-	secret, err := c.CDClient.SecretClient.GetEncryptedTextById(secretId)
+	secret, err := c.GetCDClient().SecretClient.GetEncryptedTextById(secretId)
 	if err != nil {
 		return diag.FromErr(err)
 	} else if secret == nil {
@@ -140,7 +140,7 @@ func resourceEncryptedTextCreate(ctx context.Context, d *schema.ResourceData, me
 	}
 	input.EncryptedText.UsageScope = usageScope
 
-	secret, err := c.CDClient.SecretClient.CreateEncryptedText(input)
+	secret, err := c.GetCDClient().SecretClient.CreateEncryptedText(input)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -182,7 +182,7 @@ func resourceEncryptedTextUpdate(ctx context.Context, d *schema.ResourceData, me
 	}
 	input.EncryptedText.UsageScope = usageScope
 
-	secret, err := c.CDClient.SecretClient.UpdateEncryptedText(input)
+	secret, err := c.GetCDClient().SecretClient.UpdateEncryptedText(input)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -193,7 +193,7 @@ func resourceEncryptedTextUpdate(ctx context.Context, d *schema.ResourceData, me
 func resourceEncryptedTextDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	c := meta.(*internal.Session)
 
-	if err := c.CDClient.SecretClient.DeleteSecret(d.Get("id").(string), graphql.SecretTypes.EncryptedText); err != nil {
+	if err := c.GetCDClient().SecretClient.DeleteSecret(d.Get("id").(string), graphql.SecretTypes.EncryptedText); err != nil {
 
 		// There is a racecondition that happens when resources referencing a secret are deleted by the usage count
 		// on the secret isn't updated yet. For now I'm putting in a simple 5s retry which works for now.
@@ -201,7 +201,7 @@ func resourceEncryptedTextDelete(ctx context.Context, d *schema.ResourceData, me
 		if strings.Contains(err.Error(), "still being used") {
 			log.Println("[WARN] Secret is still being used, cannot delete. Retrying in 5s...")
 			time.Sleep(time.Second * 5)
-			if err := c.CDClient.SecretClient.DeleteSecret(d.Get("id").(string), graphql.SecretTypes.EncryptedText); err != nil {
+			if err := c.GetCDClient().SecretClient.DeleteSecret(d.Get("id").(string), graphql.SecretTypes.EncryptedText); err != nil {
 				return diag.FromErr(err)
 			}
 		}
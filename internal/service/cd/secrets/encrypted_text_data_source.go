@@ -47,14 +47,14 @@ func dataSourceEncryptedTextRead(ctx context.Context, d *schema.ResourceData, me
 
 	if id := d.Get("id").(string); id != "" {
 		// Try lookup by Id first
-		secret, err = c.CDClient.SecretClient.GetEncryptedTextById(id)
+		secret, err = c.GetCDClient().SecretClient.GetEncryptedTextById(id)
 		if err != nil {
 			return diag.FromErr(err)
 		}
 	} else if name := d.Get("name").(string); name != "" {
 		// Fallback to lookup by name
 		name := d.Get("name").(string)
-		secret, err = c.CDClient.SecretClient.GetEncryptedTextByName(name)
+		secret, err = c.GetCDClient().SecretClient.GetEncryptedTextByName(name)
 		if err != nil {
 			return diag.FromErr(err)
 		}
@@ -134,11 +134,11 @@ func TestAccResourceEncryptedText_secretmanagerid_DeleteUnderlyingResource(t *te
 				PreConfig: func() {
 					acctest.TestAccConfigureProvider()
 					c := acctest.TestAccProvider.Meta().(*internal.Session)
-					secret, err := c.CDClient.SecretClient.GetEncryptedTextByName(name)
+					secret, err := c.GetCDClient().SecretClient.GetEncryptedTextByName(name)
 					require.NoError(t, err)
 					require.NotNil(t, secret)
 
-					err = c.CDClient.SecretClient.DeleteSecret(secret.Id, secret.SecretType)
+					err = c.GetCDClient().SecretClient.DeleteSecret(secret.Id, secret.SecretType)
 					require.NoError(t, err)
 				},
 				Config:             testAccResourceEncryptedText(name, value, ""),
@@ -284,5 +284,5 @@ func testAccGetEncryptedText(resourceName string, state *terraform.State) (*grap
 	c := acctest.TestAccGetApiClientFromProvider()
 	id := r.Primary.ID
 
-	return c.CDClient.SecretClient.GetEncryptedTextById(id)
+	return c.GetCDClient().SecretClient.GetEncryptedTextById(id)
 }
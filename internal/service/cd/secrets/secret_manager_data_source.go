@@ -50,7 +50,7 @@ func dataSourceSecretManagerRead(ctx context.Context, d *schema.ResourceData, me
 	// This is done because the default secret manager details are not
 	// available through the API
 	if useDefault := d.Get("default").(bool); useDefault {
-		id, err := c.CDClient.SecretClient.GetDefaultSecretManagerId()
+		id, err := c.GetCDClient().SecretClient.GetDefaultSecretManagerId()
 		if err != nil {
 			return diag.FromErr(err)
 		}
@@ -63,9 +63,9 @@ func dataSourceSecretManagerRead(ctx context.Context, d *schema.ResourceData, me
 	var err error
 
 	if id := d.Get("id").(string); id != "" {
-		sm, err = c.CDClient.SecretClient.GetSecretManagerById(id)
+		sm, err = c.GetCDClient().SecretClient.GetSecretManagerById(id)
 	} else if name := d.Get("name").(string); name != "" {
-		sm, err = c.CDClient.SecretClient.GetSecretManagerByName(name)
+		sm, err = c.GetCDClient().SecretClient.GetSecretManagerByName(name)
 	} else if err != nil {
 		return diag.FromErr(err)
 	}
@@ -202,7 +202,7 @@ func resourceSSHCredentialCreateOrUpdate(ctx context.Context, d *schema.Resource
 	if d.IsNewResource() {
 		input = &graphql.SSHCredential{}
 	} else {
-		if input, err = c.CDClient.SecretClient.GetSSHCredentialById(d.Id()); err != nil {
+		if input, err = c.GetCDClient().SecretClient.GetSSHCredentialById(d.Id()); err != nil {
 			return diag.FromErr(err)
 		} else if input == nil {
 			d.SetId("")
@@ -223,7 +223,7 @@ func resourceSSHCredentialCreateOrUpdate(ctx context.Context, d *schema.Resource
 	}
 	input.UsageScope = usageScope
 
-	cred, err := c.CDClient.SecretClient.CreateSSHCredential(input)
+	cred, err := c.GetCDClient().SecretClient.CreateSSHCredential(input)
 
 	if err != nil {
 		return diag.FromErr(err)
@@ -237,7 +237,7 @@ func resourceSSHCredentialRead(ctx context.Context, d *schema.ResourceData, meta
 
 	credId := d.Get("id").(string)
 
-	cred, err := c.CDClient.SecretClient.GetSSHCredentialById(credId)
+	cred, err := c.GetCDClient().SecretClient.GetSSHCredentialById(credId)
 
 	if err != nil {
 		return diag.FromErr(err)
@@ -274,7 +274,7 @@ func readSSHCredential(d *schema.ResourceData, secret *graphql.SSHCredential) di
 func resourceSSHCredentialDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	c := meta.(*internal.Session)
 
-	err := c.CDClient.SecretClient.DeleteSecret(d.Get("id").(string), graphql.SecretTypes.SSHCredential)
+	err := c.GetCDClient().SecretClient.DeleteSecret(d.Get("id").(string), graphql.SecretTypes.SSHCredential)
 
 	if err != nil {
 		return diag.FromErr(err)
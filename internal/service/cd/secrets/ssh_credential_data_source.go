@@ -45,9 +45,9 @@ func dataSourceSshCredentialRead(ctx context.Context, d *schema.ResourceData, me
 	var err error
 
 	if id := d.Get("id").(string); id != "" {
-		sshCred, err = c.CDClient.SecretClient.GetSSHCredentialById(id)
+		sshCred, err = c.GetCDClient().SecretClient.GetSSHCredentialById(id)
 	} else if name := d.Get("name").(string); name != "" {
-		sshCred, err = c.CDClient.SecretClient.GetSSHCredentialByName(name)
+		sshCred, err = c.GetCDClient().SecretClient.GetSSHCredentialByName(name)
 	} else if err != nil {
 		return diag.FromErr(err)
 	}
@@ -61,11 +61,11 @@ func TestAccResourceSSHCredential_SSHAuthentication_DeleteUnderlyingResource(t *
 					acctest.TestAccConfigureProvider()
 					c := acctest.TestAccProvider.Meta().(*internal.Session)
 
-					secret, err := c.CDClient.SecretClient.GetSSHCredentialByName(name)
+					secret, err := c.GetCDClient().SecretClient.GetSSHCredentialByName(name)
 					require.NoError(t, err)
 					require.NotNil(t, secret)
 
-					err = c.CDClient.SecretClient.DeleteSecret(secret.Id, secret.SecretType)
+					err = c.GetCDClient().SecretClient.DeleteSecret(secret.Id, secret.SecretType)
 					require.NoError(t, err)
 				},
 				Config:             testAccResourceSSHCredential(name, true, graphql.SSHAuthenticationTypes.SSHAuthentication),
@@ -137,7 +137,7 @@ func testAccGetSSHCredential(resourceName string, state *terraform.State) (*grap
 	c := acctest.TestAccGetApiClientFromProvider()
 	id := r.Primary.ID
 
-	return c.CDClient.SecretClient.GetSSHCredentialById(id)
+	return c.GetCDClient().SecretClient.GetSSHCredentialById(id)
 }
 
 func testAccSShCredentialCreation(t *testing.T, resourceName string, authenticationScheme graphql.SSHAuthenticationScheme) resource.TestCheckFunc {
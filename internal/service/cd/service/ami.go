@@ -20,9 +20,7 @@ func ResourceAMIService() *schema.Resource {
 
 		Schema: commonServiceSchema(),
 
-		Importer: &schema.ResourceImporter{
-			State: serviceStateImporter,
-		},
+		Importer: serviceImporter,
 	}
 }
 
@@ -35,7 +33,7 @@ func resourceAMIServiceRead(ctx context.Context, d *schema.ResourceData, meta in
 	var svc *cac.Service
 	var err error
 
-	if svc, err = c.CDClient.ConfigAsCodeClient.GetServiceById(appId, svcId); err != nil {
+	if svc, err = c.GetCDClient().ConfigAsCodeClient.GetServiceById(appId, svcId); err != nil {
 		return diag.FromErr(err)
 	} else if svc == nil {
 		d.SetId("")
@@ -68,7 +66,7 @@ func resourceAMIServiceCreateOrUpdate(ctx context.Context, d *schema.ResourceDat
 	if d.IsNewResource() {
 		input = cac.NewEntity(cac.ObjectTypes.Service).(*cac.Service)
 	} else {
-		if input, err = c.CDClient.ConfigAsCodeClient.GetServiceById(d.Get("app_id").(string), d.Id()); err != nil {
+		if input, err = c.GetCDClient().ConfigAsCodeClient.GetServiceById(d.Get("app_id").(string), d.Id()); err != nil {
 			return diag.FromErr(err)
 		} else if input == nil {
 			d.SetId("")
@@ -89,7 +87,7 @@ func resourceAMIServiceCreateOrUpdate(ctx context.Context, d *schema.ResourceDat
 	}
 
 	// Create Service
-	newSvc, err := c.CDClient.ConfigAsCodeClient.UpsertService(input)
+	newSvc, err := c.GetCDClient().ConfigAsCodeClient.UpsertService(input)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -82,11 +82,11 @@ func TestAccResourceAWSCodeDeployService_DeleteUnderlyingResource(t *testing.T)
 				PreConfig: func() {
 					acctest.TestAccConfigureProvider()
 					c := acctest.TestAccProvider.Meta().(*internal.Session)
-					svc, err := c.CDClient.ConfigAsCodeClient.GetServiceById(appId, serviceId)
+					svc, err := c.GetCDClient().ConfigAsCodeClient.GetServiceById(appId, serviceId)
 					require.NoError(t, err)
 					require.NotNil(t, svc)
 
-					err = c.CDClient.ConfigAsCodeClient.DeleteService(svc.ApplicationId, svc.Id)
+					err = c.GetCDClient().ConfigAsCodeClient.DeleteService(svc.ApplicationId, svc.Id)
 					require.NoError(t, err)
 				},
 				Config:             testAccResourceAWSCodeDeployService(name, description),
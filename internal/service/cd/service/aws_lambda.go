@@ -18,9 +18,7 @@ func ResourceAWSLambdaService() *schema.Resource {
 		UpdateContext: resourceAWSLambdaServiceCreateOrUpdate,
 		DeleteContext: resourceServiceDelete,
 		Schema:        commonServiceSchema(),
-		Importer: &schema.ResourceImporter{
-			State: serviceStateImporter,
-		},
+		Importer:      serviceImporter,
 	}
 }
 
@@ -33,7 +31,7 @@ func resourceAWSLambdaServiceRead(ctx context.Context, d *schema.ResourceData, m
 	var svc *cac.Service
 	var err error
 
-	if svc, err = c.CDClient.ConfigAsCodeClient.GetServiceById(appId, svcId); err != nil {
+	if svc, err = c.GetCDClient().ConfigAsCodeClient.GetServiceById(appId, svcId); err != nil {
 		return diag.FromErr(err)
 	} else if svc == nil {
 		d.SetId("")
@@ -66,7 +64,7 @@ func resourceAWSLambdaServiceCreateOrUpdate(ctx context.Context, d *schema.Resou
 	if d.IsNewResource() {
 		input = cac.NewEntity(cac.ObjectTypes.Service).(*cac.Service)
 	} else {
-		if input, err = c.CDClient.ConfigAsCodeClient.GetServiceById(d.Get("app_id").(string), d.Id()); err != nil {
+		if input, err = c.GetCDClient().ConfigAsCodeClient.GetServiceById(d.Get("app_id").(string), d.Id()); err != nil {
 			return diag.FromErr(err)
 		} else if input == nil {
 			d.SetId("")
@@ -87,7 +85,7 @@ func resourceAWSLambdaServiceCreateOrUpdate(ctx context.Context, d *schema.Resou
 	}
 
 	// Create Service
-	newSvc, err := c.CDClient.ConfigAsCodeClient.UpsertService(input)
+	newSvc, err := c.GetCDClient().ConfigAsCodeClient.UpsertService(input)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -2,23 +2,19 @@ package service
 
 import (
 	"context"
-	"strings"
 
 	"github.com/harness/harness-go-sdk/harness/cd/cac"
+	"github.com/harness/terraform-provider-harness/helpers"
 	"github.com/harness/terraform-provider-harness/internal"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
-func serviceStateImporter(d *schema.ResourceData, i interface{}) ([]*schema.ResourceData, error) {
-	// <app_id>/<svc_id>
-	parts := strings.Split(d.Id(), "/")
-
-	d.Set("app_id", parts[0])
-	d.SetId(parts[1])
-
-	return []*schema.ResourceData{d}, nil
-}
+// serviceImporter imports services from `<app_id>/<svc_id>`.
+var serviceImporter = (&helpers.ImportIdParser{
+	Formats:     []helpers.ImportIdFormat{{"app_id", "id"}},
+	IdAttribute: "id",
+}).Importer()
 
 func resourceServiceDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	c := meta.(*internal.Session)
@@ -26,7 +22,7 @@ func resourceServiceDelete(ctx context.Context, d *schema.ResourceData, meta int
 	id := d.Get("id").(string)
 	appId := d.Get("app_id").(string)
 
-	err := c.CDClient.ConfigAsCodeClient.DeleteService(appId, id)
+	err := c.GetCDClient().ConfigAsCodeClient.DeleteService(appId, id)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -76,7 +76,7 @@ func dataSourceServiceRead(ctx context.Context, d *schema.ResourceData, meta int
 	appId := d.Get("app_id").(string)
 	svcId := d.Get("id").(string)
 
-	svc, err := c.CDClient.ConfigAsCodeClient.GetServiceById(appId, svcId)
+	svc, err := c.GetCDClient().ConfigAsCodeClient.GetServiceById(appId, svcId)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -29,9 +29,7 @@ func ResourceSSHService() *schema.Resource {
 		UpdateContext: resourceSSHServiceCreateOrUpdate,
 		DeleteContext: resourceServiceDelete,
 		Schema:        sshSchema,
-		Importer: &schema.ResourceImporter{
-			State: serviceStateImporter,
-		},
+		Importer:      serviceImporter,
 	}
 }
 
@@ -44,7 +42,7 @@ func resourceSSHServiceRead(ctx context.Context, d *schema.ResourceData, meta in
 	var svc *cac.Service
 	var err error
 
-	if svc, err = c.CDClient.ConfigAsCodeClient.GetServiceById(appId, svcId); err != nil {
+	if svc, err = c.GetCDClient().ConfigAsCodeClient.GetServiceById(appId, svcId); err != nil {
 		return diag.FromErr(err)
 	} else if svc == nil {
 		d.SetId("")
@@ -78,7 +76,7 @@ func resourceSSHServiceCreateOrUpdate(ctx context.Context, d *schema.ResourceDat
 	if d.IsNewResource() {
 		input = cac.NewEntity(cac.ObjectTypes.Service).(*cac.Service)
 	} else {
-		if input, err = c.CDClient.ConfigAsCodeClient.GetServiceById(d.Get("app_id").(string), d.Id()); err != nil {
+		if input, err = c.GetCDClient().ConfigAsCodeClient.GetServiceById(d.Get("app_id").(string), d.Id()); err != nil {
 			return diag.FromErr(err)
 		} else if input == nil {
 			d.SetId("")
@@ -98,7 +96,7 @@ func resourceSSHServiceCreateOrUpdate(ctx context.Context, d *schema.ResourceDat
 	}
 
 	// Create Service
-	newSvc, err := c.CDClient.ConfigAsCodeClient.UpsertService(input)
+	newSvc, err := c.GetCDClient().ConfigAsCodeClient.UpsertService(input)
 	if err != nil {
 		return diag.FromErr(err)
 	}
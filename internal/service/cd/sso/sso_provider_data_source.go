@@ -49,14 +49,14 @@ func dataSourceSSOProviderRead(ctx context.Context, d *schema.ResourceData, meta
 
 	if id := d.Get("id").(string); id != "" {
 		// Try lookup by Id first
-		provider, err = c.CDClient.SSOClient.GetSSOProviderById(id)
+		provider, err = c.GetCDClient().SSOClient.GetSSOProviderById(id)
 		if err != nil {
 			return diag.FromErr(err)
 		}
 	} else if name := d.Get("name").(string); name != "" {
 		// Fallback to lookup by name
 		name := d.Get("name").(string)
-		provider, err = c.CDClient.SSOClient.GetSSOProviderByName(name)
+		provider, err = c.GetCDClient().SSOClient.GetSSOProviderByName(name)
 		if err != nil {
 			return diag.FromErr(err)
 		}
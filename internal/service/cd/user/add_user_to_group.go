@@ -5,8 +5,8 @@ import (
 	"errors"
 	"fmt"
 	"log"
-	"strings"
 
+	"github.com/harness/terraform-provider-harness/helpers"
 	"github.com/harness/terraform-provider-harness/internal"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -36,20 +36,15 @@ func ResourceAddUserToGroup() *schema.Resource {
 			},
 		},
 
-		Importer: &schema.ResourceImporter{
-			StateContext: func(ctx context.Context, d *schema.ResourceData, i interface{}) ([]*schema.ResourceData, error) {
-				// <user_id>/<group_id>
-				parts := strings.Split(d.Id(), "/")
-				d.Set("user_id", parts[0])
-				d.Set("group_id", parts[1])
-				return []*schema.ResourceData{d}, nil
-			},
-		},
+		Importer: (&helpers.ImportIdParser{
+			Formats: []helpers.ImportIdFormat{{"user_id", "group_id"}},
+			KeepId:  true,
+		}).Importer(),
 	}
 }
 
 func resourceAddUserToGroupCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	c := meta.(*internal.Session).CDClient
+	c := meta.(*internal.Session).GetCDClient()
 
 	userId := d.Get("user_id").(string)
 	groupId := d.Get("group_id").(string)
@@ -76,7 +71,7 @@ func resourceAddUserToGroupCreate(ctx context.Context, d *schema.ResourceData, m
 }
 
 func resourceAddUserToGroupRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	c := meta.(*internal.Session).CDClient
+	c := meta.(*internal.Session).GetCDClient()
 
 	userId := d.Get("user_id").(string)
 	groupId := d.Get("group_id").(string)
@@ -97,7 +92,7 @@ func resourceAddUserToGroupRead(ctx context.Context, d *schema.ResourceData, met
 }
 
 func resourceAddUserToGroupDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	c := meta.(*internal.Session).CDClient
+	c := meta.(*internal.Session).GetCDClient()
 
 	userId := d.Get("user_id").(string)
 	groupId := d.Get("group_id").(string)
@@ -41,7 +41,7 @@ func DataSourceUserGroup() *schema.Resource {
 
 func dataSourceUserGroupRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	// use the meta value to retrieve your client from the provider configure method
-	c := meta.(*internal.Session).CDClient
+	c := meta.(*internal.Session).GetCDClient()
 
 	var userGroup *graphql.UserGroup
 	var err error
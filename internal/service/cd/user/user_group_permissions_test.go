@@ -20,7 +20,7 @@ func TestAccResourceUserGroupPermissions_AccountPermissions(t *testing.T) {
 	resourceName := "harness_user_group_permissions.test"
 
 	defer func() {
-		c := acctest.TestAccGetApiClientFromProvider().CDClient
+		c := acctest.TestAccGetApiClientFromProvider().GetCDClient()
 		ug, err := c.UserClient.GetUserGroupByName(expectedName)
 		require.NoError(t, err)
 		require.NotNil(t, ug)
@@ -30,7 +30,7 @@ func TestAccResourceUserGroupPermissions_AccountPermissions(t *testing.T) {
 	resource.UnitTest(t, resource.TestCase{
 		PreCheck: func() {
 			acctest.TestAccPreCheck(t)
-			c := acctest.TestAccGetApiClientFromProvider().CDClient
+			c := acctest.TestAccGetApiClientFromProvider().GetCDClient()
 			c.UserClient.CreateUserGroup(&graphql.UserGroup{
 				Name: expectedName,
 			})
@@ -64,7 +64,7 @@ func TestAccResourceUserGroupPermissions_DeleteUnderlyingResource(t *testing.T)
 	resource.UnitTest(t, resource.TestCase{
 		PreCheck: func() {
 			acctest.TestAccPreCheck(t)
-			c := acctest.TestAccGetApiClientFromProvider().CDClient
+			c := acctest.TestAccGetApiClientFromProvider().GetCDClient()
 			c.UserClient.CreateUserGroup(&graphql.UserGroup{
 				Name: expectedName,
 			})
@@ -77,7 +77,7 @@ func TestAccResourceUserGroupPermissions_DeleteUnderlyingResource(t *testing.T)
 			{
 				PreConfig: func() {
 					acctest.TestAccConfigureProvider()
-					c := acctest.TestAccProvider.Meta().(*internal.Session).CDClient
+					c := acctest.TestAccProvider.Meta().(*internal.Session).GetCDClient()
 
 					grp, err := c.UserClient.GetUserGroupByName(expectedName)
 					require.NoError(t, err)
@@ -101,7 +101,7 @@ func TestAccResourceUserGroupPermissions_AppPermissions(t *testing.T) {
 	resourceName := "harness_user_group_permissions.test"
 
 	defer func() {
-		c := acctest.TestAccGetApiClientFromProvider().CDClient
+		c := acctest.TestAccGetApiClientFromProvider().GetCDClient()
 		ug, err := c.UserClient.GetUserGroupByName(expectedName)
 		require.NoError(t, err)
 		require.NotNil(t, ug)
@@ -111,7 +111,7 @@ func TestAccResourceUserGroupPermissions_AppPermissions(t *testing.T) {
 	resource.UnitTest(t, resource.TestCase{
 		PreCheck: func() {
 			acctest.TestAccPreCheck(t)
-			c := acctest.TestAccGetApiClientFromProvider().CDClient
+			c := acctest.TestAccGetApiClientFromProvider().GetCDClient()
 			c.UserClient.CreateUserGroup(&graphql.UserGroup{
 				Name: expectedName,
 			})
@@ -246,7 +246,7 @@ func testAccResourceUserGroupPermissions_AccountPermissions(name string) string
 func testAccUserGroupPermissionsDestroy(resourceName string) resource.TestCheckFunc {
 	return func(state *terraform.State) error {
 		r := acctest.TestAccGetResource(resourceName, state)
-		c := acctest.TestAccGetApiClientFromProvider().CDClient
+		c := acctest.TestAccGetApiClientFromProvider().GetCDClient()
 
 		id := r.Primary.ID
 
@@ -144,7 +144,7 @@ func TestAccResourceUserGroup_DeleteUnderlyingResource(t *testing.T) {
 			{
 				PreConfig: func() {
 					acctest.TestAccConfigureProvider()
-					c := acctest.TestAccProvider.Meta().(*internal.Session).CDClient
+					c := acctest.TestAccProvider.Meta().(*internal.Session).GetCDClient()
 
 					grp, err := c.UserClient.GetUserGroupByName(expectedName)
 					require.NoError(t, err)
@@ -200,7 +200,7 @@ func testAccUserGroupCreation(t *testing.T, resourceName string, name string) re
 
 func testAccGetUserGroup(resourceName string, state *terraform.State) (*graphql.UserGroup, error) {
 	r := acctest.TestAccGetResource(resourceName, state)
-	c := acctest.TestAccGetApiClientFromProvider().CDClient
+	c := acctest.TestAccGetApiClientFromProvider().GetCDClient()
 	id := r.Primary.ID
 
 	return c.UserClient.GetUserGroupById(id)
@@ -366,7 +366,7 @@ func testAccResourceUserGroupSAML(name string) string {
 }
 
 func testSweepUserGroups(r string) error {
-	c := acctest.TestAccGetApiClientFromProvider().CDClient
+	c := acctest.TestAccGetApiClientFromProvider().GetCDClient()
 
 	limit := 100
 	offset := 0
@@ -3,9 +3,9 @@ package yamlconfig
 import (
 	"context"
 	"fmt"
-	"strings"
 
 	"github.com/harness/harness-go-sdk/harness/cd/cac"
+	"github.com/harness/terraform-provider-harness/helpers"
 	"github.com/harness/terraform-provider-harness/internal"
 	"github.com/harness/terraform-provider-harness/internal/utils"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -49,20 +49,11 @@ func ResourceYamlConfig() *schema.Resource {
 				Required:    true,
 			},
 		},
-		Importer: &schema.ResourceImporter{
-			StateContext: func(ctx context.Context, d *schema.ResourceData, i interface{}) ([]*schema.ResourceData, error) {
-				// Id can either be <YAML_PATH> or <YAML_PATH>:<APP_ID>
-				parts := strings.Split(d.Id(), ":")
-
-				d.Set("path", parts[0])
-
-				if len(parts) > 1 {
-					d.Set("app_id", parts[1])
-				}
-
-				return []*schema.ResourceData{d}, nil
-			},
-		},
+		Importer: (&helpers.ImportIdParser{
+			Formats:   []helpers.ImportIdFormat{{"path", "app_id"}, {"path"}},
+			KeepId:    true,
+			Separator: ":",
+		}).Importer(),
 	}
 }
 
@@ -72,7 +63,7 @@ func resourceYamlConfigRead(ctx context.Context, d *schema.ResourceData, meta in
 	app_id := d.Get("app_id").(string)
 	path := cac.YamlPath(d.Get("path").(string))
 
-	entity, err := c.CDClient.ConfigAsCodeClient.FindYamlByPath(app_id, path)
+	entity, err := c.GetCDClient().ConfigAsCodeClient.FindYamlByPath(app_id, path)
 	if err != nil {
 		return diag.FromErr(err)
 	} else if entity == nil {
@@ -100,12 +91,12 @@ func resourceYamlConfigCreateOrUpdate(ctx context.Context, d *schema.ResourceDat
 	app_id := d.Get("app_id").(string)
 	content := d.Get("content").(string)
 
-	_, err := c.CDClient.ConfigAsCodeClient.UpsertRawYaml(path, []byte(content))
+	_, err := c.GetCDClient().ConfigAsCodeClient.UpsertRawYaml(path, []byte(content))
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	yamlEntity, err := c.CDClient.ConfigAsCodeClient.FindYamlByPath(app_id, path)
+	yamlEntity, err := c.GetCDClient().ConfigAsCodeClient.FindYamlByPath(app_id, path)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -123,7 +114,7 @@ func resourceYamlConfigDelete(ctx context.Context, d *schema.ResourceData, meta
 	path := cac.YamlPath(d.Get("path").(string))
 	content := d.Get("content").(string)
 
-	err := c.CDClient.ConfigAsCodeClient.DeleteEntityV2(path, content)
+	err := c.GetCDClient().ConfigAsCodeClient.DeleteEntityV2(path, content)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -51,7 +51,7 @@ func dataSourceYamlConfigRead(ctx context.Context, d *schema.ResourceData, meta
 	app_id := d.Get("app_id").(string)
 	path := cac.YamlPath(d.Get("path").(string))
 
-	entity, err := c.CDClient.ConfigAsCodeClient.FindYamlByPath(app_id, path)
+	entity, err := c.GetCDClient().ConfigAsCodeClient.FindYamlByPath(app_id, path)
 	if err != nil {
 		return diag.FromErr(err)
 	} else if entity == nil {
@@ -1,7 +1,6 @@
 package connector
 
 import (
-	"github.com/harness/terraform-provider-harness/helpers"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -68,7 +67,7 @@ func DatasourceConnectorAppDynamics() *schema.Resource {
 		},
 	}
 
-	helpers.SetMultiLevelDatasourceSchema(resource.Schema)
+	setConnectorDataSourceSchema(resource.Schema)
 
 	return resource
 }
@@ -63,7 +63,7 @@ func ResourceConnectorArtifactory() *schema.Resource {
 		},
 	}
 
-	helpers.SetMultiLevelResourceSchema(resource.Schema)
+	setConnectorResourceSchema(resource)
 
 	return resource
 }
@@ -74,6 +74,10 @@ func resourceConnectorArtifactoryRead(ctx context.Context, d *schema.ResourceDat
 		return err
 	}
 
+	if conn == nil {
+		return nil
+	}
+
 	if err := readConnectorArtifactory(d, conn); err != nil {
 		return diag.FromErr(err)
 	}
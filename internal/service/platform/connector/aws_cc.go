@@ -74,7 +74,7 @@ func ResourceConnectorAwsCC() *schema.Resource {
 		},
 	}
 
-	helpers.SetMultiLevelResourceSchema(resource.Schema)
+	setConnectorResourceSchema(resource)
 
 	return resource
 }
@@ -85,6 +85,10 @@ func resourceConnectorAwsCCRead(ctx context.Context, d *schema.ResourceData, met
 		return err
 	}
 
+	if conn == nil {
+		return nil
+	}
+
 	if err := readConnectorAwsCC(d, conn); err != nil {
 		return diag.FromErr(err)
 	}
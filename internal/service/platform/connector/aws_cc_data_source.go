@@ -5,7 +5,6 @@ import (
 	"strings"
 
 	"github.com/harness/harness-go-sdk/harness/nextgen"
-	"github.com/harness/terraform-provider-harness/helpers"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -66,7 +65,7 @@ func DatasourceConnectorAwsCC() *schema.Resource {
 		},
 	}
 
-	helpers.SetMultiLevelDatasourceSchema(resource.Schema)
+	setConnectorDataSourceSchema(resource.Schema)
 
 	return resource
 }
@@ -115,7 +115,7 @@ func ResourceConnectorAwsKms() *schema.Resource {
 		},
 	}
 
-	helpers.SetMultiLevelResourceSchema(resource.Schema)
+	setConnectorResourceSchema(resource)
 
 	return resource
 }
@@ -126,6 +126,10 @@ func resourceConnectorAwsKmsRead(ctx context.Context, d *schema.ResourceData, me
 		return err
 	}
 
+	if conn == nil {
+		return nil
+	}
+
 	if err := readConnectorAwsKms(d, conn); err != nil {
 		return diag.FromErr(err)
 	}
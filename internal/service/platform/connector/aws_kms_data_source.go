@@ -1,7 +1,6 @@
 package connector
 
 import (
-	"github.com/harness/terraform-provider-harness/helpers"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -87,7 +86,7 @@ func DatasourceConnectorAwsKms() *schema.Resource {
 		},
 	}
 
-	helpers.SetMultiLevelDatasourceSchema(resource.Schema)
+	setConnectorDataSourceSchema(resource.Schema)
 
 	return resource
 }
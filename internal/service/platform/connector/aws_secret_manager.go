@@ -115,7 +115,7 @@ func ResourceConnectorAwsSM() *schema.Resource {
 		},
 	}
 
-	helpers.SetMultiLevelResourceSchema(resource.Schema)
+	setConnectorResourceSchema(resource)
 
 	return resource
 }
@@ -126,6 +126,10 @@ func resourceConnectorAwsSMRead(ctx context.Context, d *schema.ResourceData, met
 		return err
 	}
 
+	if conn == nil {
+		return nil
+	}
+
 	if err := readConnectorAwsSM(d, conn); err != nil {
 		return diag.FromErr(err)
 	}
@@ -169,7 +169,7 @@ func ResourceConnectorAzureCloudProvider() *schema.Resource {
 		},
 	}
 
-	helpers.SetMultiLevelResourceSchema(resource.Schema)
+	setConnectorResourceSchema(resource)
 
 	return resource
 }
@@ -180,6 +180,10 @@ func resourceConnectorAzureCloudProviderRead(ctx context.Context, d *schema.Reso
 		return err
 	}
 
+	if conn == nil {
+		return nil
+	}
+
 	if err := readConnectorAzureCloudProvider(d, conn); err != nil {
 		return diag.FromErr(err)
 	}
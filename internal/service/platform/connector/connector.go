@@ -4,12 +4,17 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"net/url"
+	"strings"
+	"time"
 
 	"github.com/antihax/optional"
 	"github.com/harness/harness-go-sdk/harness/nextgen"
 	"github.com/harness/terraform-provider-harness/helpers"
 	"github.com/harness/terraform-provider-harness/internal"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -25,7 +30,7 @@ func resourceConnectorReadBase(ctx context.Context, d *schema.ResourceData, meta
 
 	resp, httpResp, err := c.ConnectorsApi.GetConnector(ctx, c.AccountId, id, getReadConnectorOpts(d))
 	if err != nil {
-		return nil, helpers.HandleApiError(err, d, httpResp)
+		return nil, helpers.HandleReadApiError(err, d, httpResp)
 	}
 
 	if connType != resp.Data.Connector.Type_ {
@@ -33,6 +38,7 @@ func resourceConnectorReadBase(ctx context.Context, d *schema.ResourceData, meta
 	}
 
 	readCommonConnectorData(d, resp.Data.Connector)
+	readConnectorStatus(d, resp.Data.Status)
 
 	return resp.Data.Connector, nil
 }
@@ -78,24 +84,233 @@ func resourceConnectorCreateOrUpdateBase(ctx context.Context, d *schema.Resource
 	}
 
 	readCommonConnectorData(d, resp.Data.Connector)
+	readConnectorStatus(d, resp.Data.Status)
+
+	if diags := validateConnectorConnection(ctx, d, meta); diags.HasError() {
+		if id != "" {
+			// Keep the previous state, so the update is planned and tested again.
+			d.Partial(true)
+		}
+		return nil, diags
+	}
 
 	return resp.Data.Connector, nil
 }
 
+// setConnectorResourceSchema sets the schema objects and the diff customization shared by all connector resources.
+func setConnectorResourceSchema(r *schema.Resource) {
+	if r.CustomizeDiff != nil {
+		r.CustomizeDiff = customdiff.Sequence(r.CustomizeDiff, connectionCustomizeDiff)
+	} else {
+		r.CustomizeDiff = connectionCustomizeDiff
+	}
+
+	s := r.Schema
+	helpers.SetMultiLevelResourceSchema(s)
+	s["force_delete"] = &schema.Schema{
+		Description: "Delete the connector even if it is still referenced by other entities, e.g. pipelines.",
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Default:     false,
+	}
+	s["validate_connection"] = &schema.Schema{
+		Description: "Test the connection of the connector after creating or updating it, and fail when the test doesn't succeed. " +
+			"A connector that fails the test is still saved, and the next apply replaces it when it was created or updates and tests it again when it was updated.",
+		Type:     schema.TypeBool,
+		Optional: true,
+		Default:  false,
+	}
+	s["validation_timeout"] = &schema.Schema{
+		Description:  "How long to wait for the connection test when `validate_connection` is set, e.g. `30s` or `5m`.",
+		Type:         schema.TypeString,
+		Optional:     true,
+		Default:      defaultValidationTimeout,
+		ValidateFunc: validateDuration,
+	}
+	setConnectivitySchema(s)
+}
+
+// connectionCustomizeDiff plans an update of a connector whose last connection test failed when
+// `validate_connection` is set, so the test runs again even after a refresh picked up the saved changes.
+// Connectors that were never tested aren't updated.
+func connectionCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() == "" || !d.Get("validate_connection").(bool) || d.Get("connectivity_status").(string) != "FAILURE" {
+		return nil
+	}
+
+	if err := d.SetNewComputed("connectivity_status"); err != nil {
+		return err
+	}
+	return d.SetNewComputed("last_tested_at")
+}
+
+// setConnectorDataSourceSchema sets the schema objects shared by all connector data sources.
+func setConnectorDataSourceSchema(s map[string]*schema.Schema) {
+	helpers.SetMultiLevelDatasourceSchema(s)
+	setConnectivitySchema(s)
+}
+
+func setConnectivitySchema(s map[string]*schema.Schema) {
+	s["connectivity_status"] = &schema.Schema{
+		Description: "Status of the last connection test of the connector, e.g. `SUCCESS` or `FAILURE`.",
+		Type:        schema.TypeString,
+		Computed:    true,
+	}
+	s["last_tested_at"] = &schema.Schema{
+		Description: "Time of the last connection test of the connector, in RFC 3339 format.",
+		Type:        schema.TypeString,
+		Computed:    true,
+	}
+}
+
+const defaultValidationTimeout = "2m"
+
+func validateDuration(i interface{}, k string) ([]string, []error) {
+	if _, err := time.ParseDuration(i.(string)); err != nil {
+		return nil, []error{fmt.Errorf("%s: %q is not a valid duration, e.g. `30s` or `5m`", k, i)}
+	}
+	return nil, nil
+}
+
+func readConnectorStatus(d *schema.ResourceData, status *nextgen.ConnectorConnectivityDetails) {
+	if status == nil {
+		status = &nextgen.ConnectorConnectivityDetails{}
+	}
+
+	testedAt := status.LastTestedAt
+	if status.TestedAt > testedAt {
+		testedAt = status.TestedAt
+	}
+
+	d.Set("connectivity_status", status.Status)
+	d.Set("last_tested_at", formatTestedAt(testedAt))
+}
+
+// formatTestedAt formats the time of a connection test, given in milliseconds since the epoch.
+func formatTestedAt(testedAt int64) string {
+	if testedAt == 0 {
+		return ""
+	}
+	return time.UnixMilli(testedAt).UTC().Format(time.RFC3339)
+}
+
+// validateConnectorConnection tests the connection of the connector when `validate_connection` is
+// set and fails with the errors reported by the delegate when the test doesn't succeed.
+func validateConnectorConnection(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if !d.Get("validate_connection").(bool) {
+		return nil
+	}
+
+	timeout, err := time.ParseDuration(d.Get("validation_timeout").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	c, ctx := meta.(*internal.Session).GetPlatformClientWithContext(ctx)
+	resp, httpResp, err := c.ConnectorsApi.GetTestConnectionResult(ctx, c.AccountId, d.Id(), &nextgen.ConnectorsApiGetTestConnectionResultOpts{
+		OrgIdentifier:     helpers.BuildField(d, "org_id"),
+		ProjectIdentifier: helpers.BuildField(d, "project_id"),
+	})
+	if err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			return diag.Errorf("the connection test of connector %s didn't finish within the validation_timeout of %s", d.Id(), timeout)
+		}
+		return helpers.HandleApiError(err, d, httpResp)
+	}
+
+	if resp.Data == nil {
+		return diag.Errorf("the connection test of connector %s returned no result", d.Id())
+	}
+
+	d.Set("connectivity_status", resp.Data.Status)
+	d.Set("last_tested_at", formatTestedAt(resp.Data.TestedAt))
+
+	return connectionTestDiagnostics(d.Id(), resp.Data)
+}
+
+func connectionTestDiagnostics(id string, result *nextgen.ConnectorValidationResult) diag.Diagnostics {
+	if result.Status == "SUCCESS" {
+		return nil
+	}
+
+	summary := result.ErrorSummary
+	if summary == "" {
+		summary = fmt.Sprintf("status %s", result.Status)
+	}
+
+	var details []string
+	for _, e := range result.Errors {
+		if e.Reason != "" {
+			details = append(details, fmt.Sprintf("%s: %s", e.Reason, e.Message))
+		} else {
+			details = append(details, e.Message)
+		}
+	}
+	if result.DelegateId != "" {
+		details = append(details, fmt.Sprintf("Delegate: %s", result.DelegateId))
+	}
+
+	return diag.Diagnostics{{
+		Severity: diag.Error,
+		Summary:  fmt.Sprintf("the connection test of connector %s failed: %s", id, summary),
+		Detail:   strings.Join(details, "\n"),
+	}}
+}
+
 func resourceConnectorDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	c, ctx := meta.(*internal.Session).GetPlatformClientWithContext(ctx)
 
+	if d.Get("force_delete").(bool) {
+		ctx = internal.WithQueryParams(ctx, url.Values{"forceDelete": {"true"}})
+	}
+
 	_, httpResp, err := c.ConnectorsApi.DeleteConnector(ctx, c.AccountId, d.Id(), &nextgen.ConnectorsApiDeleteConnectorOpts{
 		OrgIdentifier:     helpers.BuildField(d, "org_id"),
 		ProjectIdentifier: helpers.BuildField(d, "project_id")})
 
 	if err != nil {
-		helpers.HandleApiError(err, d, httpResp)
+		if helpers.IsNotFound(err, httpResp) {
+			return nil
+		}
+		return helpers.HandleApiError(err, d, httpResp)
+	}
+
+	// Deleting is eventually consistent, so wait until the connector can't be read anymore. Otherwise
+	// creating a connector with the same identifier straight away fails.
+	stateConf := &resource.StateChangeConf{
+		Pending:    []string{"deleting"},
+		Target:     []string{"deleted"},
+		Refresh:    connectorDeletedRefreshFunc(ctx, meta.(*internal.Session), d),
+		Timeout:    d.Timeout(schema.TimeoutDelete),
+		MinTimeout: time.Second,
+		Delay:      time.Second,
+	}
+
+	if _, err := stateConf.WaitForStateContext(ctx); err != nil {
+		return diag.Errorf("error waiting for connector %s to be deleted: %s", d.Id(), err)
 	}
 
 	return nil
 }
 
+// connectorDeletedRefreshFunc reads the connector as JSON, so connectors of any type can be waited for.
+func connectorDeletedRefreshFunc(ctx context.Context, session *internal.Session, d *schema.ResourceData) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		resp, httpResp, err := getConnectorJson(ctx, session, d.Id(), d.Get("org_id").(string), d.Get("project_id").(string))
+		if err != nil {
+			if helpers.IsNotFound(err, httpResp) {
+				return d.Id(), "deleted", nil
+			}
+			return nil, "", err
+		}
+
+		return resp, "deleting", nil
+	}
+}
+
 func buildConnector(d *schema.ResourceData, connector *nextgen.ConnectorInfo) {
 	if attr := d.Get("name").(string); attr != "" {
 		connector.Name = attr
@@ -130,4 +345,9 @@ func readCommonConnectorData(d *schema.ResourceData, connector *nextgen.Connecto
 	d.Set("org_id", connector.OrgIdentifier)
 	d.Set("project_id", connector.ProjectIdentifier)
 	d.Set("tags", helpers.FlattenTags(connector.Tags))
+
+	// force_delete isn't stored by Harness, so default it when the connector is imported.
+	if _, ok := d.GetOk("force_delete"); !ok {
+		d.Set("force_delete", false)
+	}
 }
@@ -0,0 +1,143 @@
+package connector
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/harness/harness-go-sdk/harness/nextgen"
+	"github.com/harness/terraform-provider-harness/internal"
+	"github.com/hashicorp/go-retryablehttp"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/require"
+)
+
+// testSession returns a session whose platform client sends its requests to the given handler.
+func testSession(t *testing.T, handler http.HandlerFunc) *internal.Session {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	httpClient := retryablehttp.NewClient()
+	httpClient.RetryMax = 0
+	return &internal.Session{
+		AccountId: "test",
+		NewPLClient: func() *nextgen.APIClient {
+			return nextgen.NewAPIClient(&nextgen.Configuration{AccountId: "test", BasePath: server.URL, ApiKey: "key", HTTPClient: httpClient})
+		},
+		PLHTTPClient: httpClient,
+	}
+}
+
+func testJiraConnectorState(status string) *terraform.InstanceState {
+	return &terraform.InstanceState{
+		ID: "test",
+		Attributes: map[string]string{
+			"id":                  "test",
+			"identifier":          "test",
+			"name":                "test",
+			"url":                 "https://jira.com",
+			"password_ref":        "account.secret",
+			"force_delete":        "false",
+			"validate_connection": "true",
+			"validation_timeout":  "2m",
+			"connectivity_status": status,
+		},
+	}
+}
+
+func testJiraConnectorConfig(url string, validate bool) *terraform.ResourceConfig {
+	return terraform.NewResourceConfigRaw(map[string]interface{}{
+		"identifier":          "test",
+		"name":                "test",
+		"url":                 url,
+		"password_ref":        "account.secret",
+		"validate_connection": validate,
+	})
+}
+
+func TestConnectorUpdateConnectionTestFailed(t *testing.T) {
+	session := testSession(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == http.MethodPost {
+			w.Write([]byte(`{"status":"SUCCESS","data":{"status":"FAILURE","errorSummary":"Invalid credentials"}}`))
+			return
+		}
+		w.Write([]byte(`{"status":"SUCCESS","data":{"connector":{"identifier":"test","name":"test","type":"Jira",` +
+			`"spec":{"jiraUrl":"https://other.com","passwordRef":"account.secret"}},"status":{"status":"FAILURE"}}}`))
+	})
+
+	ctx := context.Background()
+	r := ResourceConnectorJira()
+	state := testJiraConnectorState("SUCCESS")
+
+	diff, err := r.Diff(ctx, state, testJiraConnectorConfig("https://other.com", true), session)
+	require.NoError(t, err)
+
+	// The update is saved by Harness, but the state keeps the previous configuration so it's planned again.
+	newState, diags := r.Apply(ctx, state, diff, session)
+	require.True(t, diags.HasError())
+	require.Equal(t, "the connection test of connector test failed: Invalid credentials", diags[0].Summary)
+	require.Equal(t, "https://jira.com", newState.Attributes["url"])
+	require.Equal(t, "SUCCESS", newState.Attributes["connectivity_status"])
+}
+
+func TestConnectionCustomizeDiff(t *testing.T) {
+	ctx := context.Background()
+	r := ResourceConnectorJira()
+
+	diff, err := r.Diff(ctx, testJiraConnectorState("SUCCESS"), testJiraConnectorConfig("https://jira.com", true), nil)
+	require.NoError(t, err)
+	require.Nil(t, diff)
+
+	// Connectors that were never tested aren't updated.
+	diff, err = r.Diff(ctx, testJiraConnectorState(""), testJiraConnectorConfig("https://jira.com", true), nil)
+	require.NoError(t, err)
+	require.Nil(t, diff)
+
+	// Connectors that failed their last connection test are updated and tested again.
+	diff, err = r.Diff(ctx, testJiraConnectorState("FAILURE"), testJiraConnectorConfig("https://jira.com", true), nil)
+	require.NoError(t, err)
+	require.True(t, diff.Attributes["connectivity_status"].NewComputed)
+	require.False(t, diff.RequiresNew())
+
+	// Unless the connection isn't validated.
+	state := testJiraConnectorState("FAILURE")
+	state.Attributes["validate_connection"] = "false"
+	diff, err = r.Diff(ctx, state, testJiraConnectorConfig("https://jira.com", false), nil)
+	require.NoError(t, err)
+	require.Nil(t, diff)
+}
+
+func TestConnectionTestDiagnostics(t *testing.T) {
+	require.Nil(t, connectionTestDiagnostics("test", &nextgen.ConnectorValidationResult{Status: "SUCCESS"}))
+
+	diags := connectionTestDiagnostics("test", &nextgen.ConnectorValidationResult{
+		Status:       "FAILURE",
+		ErrorSummary: "Invalid credentials",
+		Errors: []nextgen.ErrorDetail{
+			{Reason: "Unexpected Error", Message: "401 Unauthorized"},
+			{Message: "Check the username and password"},
+		},
+		DelegateId: "delegate",
+	})
+	require.True(t, diags.HasError())
+	require.Equal(t, "the connection test of connector test failed: Invalid credentials", diags[0].Summary)
+	require.Equal(t, "Unexpected Error: 401 Unauthorized\nCheck the username and password\nDelegate: delegate", diags[0].Detail)
+
+	diags = connectionTestDiagnostics("test", &nextgen.ConnectorValidationResult{Status: "PARTIAL"})
+	require.Equal(t, "the connection test of connector test failed: status PARTIAL", diags[0].Summary)
+}
+
+func TestFormatTestedAt(t *testing.T) {
+	require.Equal(t, "", formatTestedAt(0))
+	require.Equal(t, "2022-01-01T00:00:00Z", formatTestedAt(1640995200000))
+}
+
+func TestValidateDuration(t *testing.T) {
+	_, errs := validateDuration("30s", "validation_timeout")
+	require.Empty(t, errs)
+
+	_, errs = validateDuration("30", "validation_timeout")
+	require.Len(t, errs, 1)
+}
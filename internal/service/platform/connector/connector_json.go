@@ -0,0 +1,111 @@
+package connector
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/harness/harness-go-sdk/harness/nextgen"
+	"github.com/harness/terraform-provider-harness/internal"
+)
+
+// connectorJson is a connector of any type. The SDK only decodes the connector types it models and
+// panics on the others, so the generic connector resources read and write connectors as JSON.
+type connectorJson struct {
+	Name              string                 `json:"name"`
+	Identifier        string                 `json:"identifier"`
+	Description       string                 `json:"description,omitempty"`
+	OrgIdentifier     string                 `json:"orgIdentifier,omitempty"`
+	ProjectIdentifier string                 `json:"projectIdentifier,omitempty"`
+	Tags              map[string]string      `json:"tags,omitempty"`
+	Type_             string                 `json:"type"`
+	Spec              map[string]interface{} `json:"spec"`
+}
+
+type connectorJsonResponse struct {
+	Connector *connectorJson                        `json:"connector"`
+	Status    *nextgen.ConnectorConnectivityDetails `json:"status"`
+}
+
+func connectorScopeQuery(orgId string, projectId string) url.Values {
+	query := url.Values{}
+	if orgId != "" {
+		query.Set("orgIdentifier", orgId)
+	}
+	if projectId != "" {
+		query.Set("projectIdentifier", projectId)
+	}
+	return query
+}
+
+func getConnectorJson(ctx context.Context, session *internal.Session, id string, orgId string, projectId string) (*connectorJsonResponse, *http.Response, error) {
+	var resp struct {
+		Data *connectorJsonResponse `json:"data"`
+	}
+
+	httpResp, err := session.PlatformRequest(ctx, http.MethodGet, "/ng/api/connectors/"+url.PathEscape(id), connectorScopeQuery(orgId, projectId), nil, &resp)
+	if err != nil {
+		return nil, httpResp, err
+	}
+
+	return resp.Data, httpResp, nil
+}
+
+// getConnectorJsonByName returns the connector with the given name in a scope, or nil when there is
+// none.
+func getConnectorJsonByName(ctx context.Context, session *internal.Session, name string, orgId string, projectId string) (*connectorJsonResponse, *http.Response, error) {
+	filter := nextgen.ConnectorFilterProperties{
+		ConnectorNames: []string{name},
+		FilterType:     nextgen.ConnectorFilterTypes.Connector,
+	}
+
+	for pageIndex := 0; ; pageIndex++ {
+		var resp struct {
+			Data struct {
+				Content    []*connectorJsonResponse `json:"content"`
+				TotalPages int                      `json:"totalPages"`
+			} `json:"data"`
+		}
+
+		query := connectorScopeQuery(orgId, projectId)
+		query.Set("pageIndex", strconv.Itoa(pageIndex))
+		query.Set("pageSize", "100")
+
+		httpResp, err := session.PlatformRequest(ctx, http.MethodPost, "/ng/api/connectors/listV2", query, filter, &resp)
+		if err != nil {
+			return nil, httpResp, err
+		}
+
+		// The name filter also matches connectors whose name contains the given one.
+		for _, c := range resp.Data.Content {
+			if c.Connector != nil && c.Connector.Name == name {
+				return c, httpResp, nil
+			}
+		}
+
+		if pageIndex+1 >= resp.Data.TotalPages {
+			return nil, httpResp, nil
+		}
+	}
+}
+
+// saveConnectorJson creates the connector or, when update is set, updates it.
+func saveConnectorJson(ctx context.Context, session *internal.Session, connector *connectorJson, update bool) (*connectorJsonResponse, *http.Response, error) {
+	var resp struct {
+		Data *connectorJsonResponse `json:"data"`
+	}
+
+	method := http.MethodPost
+	if update {
+		method = http.MethodPut
+	}
+
+	body := map[string]interface{}{"connector": connector}
+	httpResp, err := session.PlatformRequest(ctx, method, "/ng/api/connectors", nil, body, &resp)
+	if err != nil {
+		return nil, httpResp, err
+	}
+
+	return resp.Data, httpResp, nil
+}
@@ -2,9 +2,12 @@ package connector_test
 
 import (
 	"fmt"
+	"regexp"
+	"testing"
 
 	"github.com/antihax/optional"
 	"github.com/harness/harness-go-sdk/harness/nextgen"
+	"github.com/harness/harness-go-sdk/harness/utils"
 	"github.com/harness/terraform-provider-harness/internal/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
@@ -55,3 +58,128 @@ func testAccGetConnector(resourceName string, state *terraform.State) (*nextgen.
 
 	return resp.Data.Connector, nil
 }
+
+func TestAccResourceConnector_DeleteReferenced(t *testing.T) {
+	id := fmt.Sprintf("%s_%s", t.Name(), utils.RandStringBytes(5))
+	resourceName := "harness_platform_connector_kubernetes.test"
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { acctest.TestAccPreCheck(t) },
+		ProviderFactories: acctest.ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceConnectorReferenced(id, false, true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "id", id),
+					resource.TestCheckResourceAttr(resourceName, "force_delete", "false"),
+				),
+			},
+			{
+				// The pipeline still references the connector so deleting it must fail.
+				Config:      testAccResourceConnectorReferenced(id, false, false),
+				ExpectError: regexp.MustCompile(`(?i)referenced`),
+			},
+			{
+				Config: testAccResourceConnectorReferenced(id, true, true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "id", id),
+					resource.TestCheckResourceAttr(resourceName, "force_delete", "true"),
+				),
+			},
+			{
+				Config: testAccResourceConnectorReferenced(id, true, false),
+				Check:  testAccConnectorDeleted(id),
+			},
+		},
+	})
+}
+
+func testAccConnectorDeleted(id string) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		c, ctx := acctest.TestAccGetPlatformClientWithContext()
+		_, _, err := c.ConnectorsApi.GetConnector(ctx, c.AccountId, id, &nextgen.ConnectorsApiGetConnectorOpts{
+			OrgIdentifier:     optional.NewString(id),
+			ProjectIdentifier: optional.NewString(id),
+		})
+		if err == nil {
+			return fmt.Errorf("Found connector: %s", id)
+		}
+
+		return nil
+	}
+}
+
+func testAccResourceConnectorReferenced(id string, forceDelete bool, withConnector bool) string {
+	connector := ""
+	if withConnector {
+		connector = fmt.Sprintf(`
+		resource "harness_platform_connector_kubernetes" "test" {
+			identifier = "%[1]s"
+			name = "%[1]s"
+			org_id = harness_platform_project.test.org_id
+			project_id = harness_platform_project.test.id
+			force_delete = %[2]t
+
+			inherit_from_delegate {
+				delegate_selectors = ["harness-delegate"]
+			}
+		}
+`, id, forceDelete)
+	}
+
+	return fmt.Sprintf(`
+		resource "harness_platform_organization" "test" {
+			identifier = "%[1]s"
+			name = "%[1]s"
+		}
+
+		resource "harness_platform_project" "test" {
+			identifier = "%[1]s"
+			name = "%[1]s"
+			org_id = harness_platform_organization.test.id
+		}
+%[2]s
+		resource "harness_platform_pipeline" "test" {
+			identifier = "%[1]s"
+			org_id = harness_platform_project.test.org_id
+			project_id = harness_platform_project.test.id
+			name = "%[1]s"
+			yaml = <<-EOT
+				pipeline:
+				    name: %[1]s
+				    identifier: %[1]s
+				    projectIdentifier: ${harness_platform_project.test.id}
+				    orgIdentifier: ${harness_platform_project.test.org_id}
+				    stages:
+				        - stage:
+				            name: dep
+				            identifier: dep
+				            type: Deployment
+				            spec:
+				                serviceConfig:
+				                    serviceRef: service
+				                    serviceDefinition:
+				                        type: Kubernetes
+				                        spec:
+				                            variables: []
+				                infrastructure:
+				                    environmentRef: testenv
+				                    infrastructureDefinition:
+				                        type: KubernetesDirect
+				                        spec:
+				                            connectorRef: %[1]s
+				                            namespace: test
+				                            releaseName: release-<+INFRA_KEY>
+				                execution:
+				                    steps:
+				                        - step:
+				                            name: Rolling Deployment
+				                            identifier: rollingDeployment
+				                            type: K8sRollingDeploy
+				                            timeout: 10m
+				                            spec:
+				                                skipDryRun: false
+			EOT
+		}
+`, id, connector)
+}
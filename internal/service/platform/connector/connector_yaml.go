@@ -0,0 +1,253 @@
+package connector
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/harness/harness-go-sdk/harness/nextgen"
+	"github.com/harness/terraform-provider-harness/helpers"
+	"github.com/harness/terraform-provider-harness/internal"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"gopkg.in/yaml.v3"
+)
+
+func ResourceConnectorYaml() *schema.Resource {
+	resource := &schema.Resource{
+		Description:   "Resource for creating a connector of any type from its YAML or JSON definition. Use it for connector types that don't have a dedicated resource yet.",
+		ReadContext:   resourceConnectorYamlRead,
+		CreateContext: resourceConnectorYamlCreateOrUpdate,
+		UpdateContext: resourceConnectorYamlCreateOrUpdate,
+		DeleteContext: resourceConnectorDelete,
+		Importer:      helpers.MultiLevelResourceImporter,
+		CustomizeDiff: customdiff.Sequence(
+			helpers.YamlConsistencyCustomizeDiff("connector", map[string]string{
+				"identifier":  "identifier",
+				"name":        "name",
+				"description": "description",
+				"org_id":      "orgIdentifier",
+				"project_id":  "projectIdentifier",
+			}),
+			connectorYamlCustomizeDiff,
+		),
+
+		Schema: map[string]*schema.Schema{
+			"yaml": {
+				Description: "YAML or JSON definition of the connector, with a single `connector` root key holding its `type` and `spec`. " +
+					"The identifier, name, description, scope and tags are taken from the resource attributes and can be left out. Changing the type replaces the connector.",
+				Type:             schema.TypeString,
+				Required:         true,
+				DiffSuppressFunc: helpers.YamlDiffSuppressFunc,
+			},
+			"type": {
+				Description: "The type of the connector.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+	}
+
+	setConnectorResourceSchema(resource)
+
+	return resource
+}
+
+func resourceConnectorYamlRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	resp, httpResp, err := getConnectorJson(ctx, meta.(*internal.Session), d.Id(), d.Get("org_id").(string), d.Get("project_id").(string))
+	if err != nil {
+		return helpers.HandleReadApiError(err, d, httpResp)
+	}
+
+	if resp == nil || resp.Connector == nil {
+		log.Printf("[WARN] %s not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	if err := readConnectorYaml(d, resp); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceConnectorYamlCreateOrUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	connector, err := buildConnectorYaml(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	update := d.Id() != ""
+	resp, httpResp, err := saveConnectorJson(ctx, meta.(*internal.Session), connector, update)
+	if err != nil {
+		return helpers.HandleApiError(err, d, httpResp)
+	}
+
+	if err := readConnectorYaml(d, resp); err != nil {
+		return diag.FromErr(err)
+	}
+
+	diags := validateConnectorConnection(ctx, d, meta)
+	if diags.HasError() && update {
+		// Keep the previous state, so the update is planned and tested again.
+		d.Partial(true)
+	}
+	return diags
+}
+
+// parseConnectorYaml returns the `connector` mapping of a connector YAML or JSON definition.
+func parseConnectorYaml(s string) (map[string]interface{}, error) {
+	doc := map[string]interface{}{}
+	if err := yaml.Unmarshal([]byte(s), &doc); err != nil {
+		return nil, fmt.Errorf("yaml: invalid YAML: %s", err)
+	}
+
+	connector, ok := doc["connector"].(map[string]interface{})
+	if !ok {
+		return nil, errors.New("yaml: expected a `connector` mapping")
+	}
+
+	return connector, nil
+}
+
+// connectorYamlCustomizeDiff checks that the YAML sets the connector type and plans the replacement
+// of the connector when the type changes, which Harness doesn't allow on update.
+func connectorYamlCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("yaml") {
+		return nil
+	}
+
+	connector, err := parseConnectorYaml(d.Get("yaml").(string))
+	if err != nil {
+		return err
+	}
+
+	if _, ok := connector["tags"]; ok {
+		return errors.New("yaml: `connector.tags` can't be set, use the `tags` attribute instead")
+	}
+
+	connType, _ := connector["type"].(string)
+	if connType == "" {
+		return errors.New("yaml: `connector.type` must be set")
+	}
+
+	if d.Get("type").(string) == connType {
+		return nil
+	}
+
+	if err := d.SetNew("type", connType); err != nil {
+		return err
+	}
+	if d.Id() != "" {
+		return d.ForceNew("type")
+	}
+
+	return nil
+}
+
+func buildConnectorYaml(d *schema.ResourceData) (*connectorJson, error) {
+	definition, err := parseConnectorYaml(d.Get("yaml").(string))
+	if err != nil {
+		return nil, err
+	}
+
+	connector := &connectorJson{
+		Name:              d.Get("name").(string),
+		Identifier:        d.Get("identifier").(string),
+		Description:       d.Get("description").(string),
+		OrgIdentifier:     d.Get("org_id").(string),
+		ProjectIdentifier: d.Get("project_id").(string),
+		Tags:              helpers.ExpandTags(d.Get("tags").(*schema.Set).List()),
+		Spec:              map[string]interface{}{},
+	}
+	connector.Type_, _ = definition["type"].(string)
+
+	if spec, ok := definition["spec"]; ok && spec != nil {
+		if connector.Spec, ok = spec.(map[string]interface{}); !ok {
+			return nil, errors.New("yaml: `connector.spec` must be a mapping")
+		}
+	}
+
+	return connector, nil
+}
+
+func readConnectorYaml(d *schema.ResourceData, resp *connectorJsonResponse) error {
+	connector := resp.Connector
+	readCommonConnectorData(d, &nextgen.ConnectorInfo{
+		Name:              connector.Name,
+		Identifier:        connector.Identifier,
+		Description:       connector.Description,
+		OrgIdentifier:     connector.OrgIdentifier,
+		ProjectIdentifier: connector.ProjectIdentifier,
+		Tags:              connector.Tags,
+	})
+	d.Set("type", connector.Type_)
+	readConnectorStatus(d, resp.Status)
+
+	doc, err := flattenConnectorYaml(d.Get("yaml").(string), connector)
+	if err != nil {
+		return err
+	}
+	d.Set("yaml", doc)
+
+	return nil
+}
+
+// flattenConnectorYaml returns the YAML to store for a connector. The configured YAML is kept when
+// the connector agrees with it, ignoring the fields it leaves out, since Harness returns the spec
+// with all the fields of the connector type. Otherwise the connector is rendered as YAML, which
+// shows the drift.
+func flattenConnectorYaml(current string, connector *connectorJson) (string, error) {
+	rendered := map[string]interface{}{
+		"name":       connector.Name,
+		"identifier": connector.Identifier,
+		"type":       connector.Type_,
+		"spec":       connector.Spec,
+	}
+	if connector.Description != "" {
+		rendered["description"] = connector.Description
+	}
+	if connector.OrgIdentifier != "" {
+		rendered["orgIdentifier"] = connector.OrgIdentifier
+	}
+	if connector.ProjectIdentifier != "" {
+		rendered["projectIdentifier"] = connector.ProjectIdentifier
+	}
+	doc := map[string]interface{}{"connector": rendered}
+
+	if configured, err := parseConnectorYaml(current); err == nil {
+		subset, err := yaml.Marshal(map[string]interface{}{"connector": yamlSubset(rendered, configured)})
+		if err != nil {
+			return "", err
+		}
+		if helpers.YamlEqual(current, string(subset)) {
+			return current, nil
+		}
+	}
+
+	out, err := yaml.Marshal(doc)
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
+
+// yamlSubset returns v with only the mapping keys that are also in template, recursively.
+func yamlSubset(v interface{}, template interface{}) interface{} {
+	m, ok := v.(map[string]interface{})
+	t, tok := template.(map[string]interface{})
+	if !ok || !tok {
+		return v
+	}
+
+	subset := make(map[string]interface{}, len(t))
+	for k, tv := range t {
+		if value, ok := m[k]; ok {
+			subset[k] = yamlSubset(value, tv)
+		}
+	}
+	return subset
+}
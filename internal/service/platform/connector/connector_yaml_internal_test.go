@@ -0,0 +1,82 @@
+package connector
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFlattenConnectorYaml(t *testing.T) {
+	connector := &connectorJson{
+		Name:       "test",
+		Identifier: "test",
+		Type_:      "ServiceNow",
+		Spec: map[string]interface{}{
+			"serviceNowUrl":     "https://servicenow.com",
+			"delegateSelectors": []interface{}{},
+			"auth": map[string]interface{}{
+				"type": "UsernamePassword",
+				"spec": map[string]interface{}{"username": "admin", "passwordRef": "account.secret"},
+			},
+		},
+	}
+
+	// Fields added by Harness are ignored.
+	configured := `{"connector": {"type": "ServiceNow", "spec": {"serviceNowUrl": "https://servicenow.com", "auth": {"type": "UsernamePassword"}}}}`
+	doc, err := flattenConnectorYaml(configured, connector)
+	require.NoError(t, err)
+	require.Equal(t, configured, doc)
+
+	// Changes of configured fields are rendered.
+	connector.Spec["serviceNowUrl"] = "https://other.com"
+	doc, err = flattenConnectorYaml(configured, connector)
+	require.NoError(t, err)
+	require.Contains(t, doc, "serviceNowUrl: https://other.com")
+	require.Contains(t, doc, "passwordRef: account.secret")
+
+	// Imported connectors have no configured YAML.
+	doc, err = flattenConnectorYaml("", connector)
+	require.NoError(t, err)
+	require.Contains(t, doc, "type: ServiceNow")
+}
+
+func TestBuildConnectorYaml(t *testing.T) {
+	d := ResourceConnectorYaml().TestResourceData()
+	d.Set("identifier", "test")
+	d.Set("name", "test")
+	d.Set("org_id", "org")
+	d.Set("tags", []interface{}{"foo:bar"})
+	d.Set("yaml", "connector:\n  name: test\n  type: Jenkins\n  spec:\n    jenkinsUrl: https://jenkins.com\n")
+
+	connector, err := buildConnectorYaml(d)
+	require.NoError(t, err)
+	require.Equal(t, "Jenkins", connector.Type_)
+	require.Equal(t, "org", connector.OrgIdentifier)
+	require.Equal(t, map[string]string{"foo": "bar"}, connector.Tags)
+	require.Equal(t, map[string]interface{}{"jenkinsUrl": "https://jenkins.com"}, connector.Spec)
+
+	d.Set("yaml", "connector:\n  type: Jenkins\n  spec: []\n")
+	_, err = buildConnectorYaml(d)
+	require.EqualError(t, err, "yaml: `connector.spec` must be a mapping")
+
+	d.Set("yaml", "pipeline: {}")
+	_, err = buildConnectorYaml(d)
+	require.EqualError(t, err, "yaml: expected a `connector` mapping")
+}
+
+func TestResourceConnectorYamlReadDeleted(t *testing.T) {
+	// Connectors deleted outside of Terraform may be returned without a connector.
+	session := testSession(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"status":"SUCCESS","data":null}`))
+	})
+
+	r := ResourceConnectorYaml()
+	d := r.TestResourceData()
+	d.SetId("test")
+
+	require.Nil(t, resourceConnectorYamlRead(context.Background(), d, session))
+	require.Empty(t, d.Id())
+}
@@ -0,0 +1,96 @@
+package connector_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/harness/harness-go-sdk/harness/utils"
+	"github.com/harness/terraform-provider-harness/internal/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccResourceConnectorYaml(t *testing.T) {
+
+	id := fmt.Sprintf("%s_%s", t.Name(), utils.RandStringBytes(5))
+	name := id
+	updatedName := fmt.Sprintf("%s_updated", name)
+	resourceName := "harness_platform_connector_yaml.test"
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { acctest.TestAccPreCheck(t) },
+		ProviderFactories: acctest.ProviderFactories,
+		CheckDestroy:      testAccConnectorYamlDestroy(resourceName),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceConnectorYaml(id, name),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "id", id),
+					resource.TestCheckResourceAttr(resourceName, "identifier", id),
+					resource.TestCheckResourceAttr(resourceName, "name", name),
+					resource.TestCheckResourceAttr(resourceName, "description", "test"),
+					resource.TestCheckResourceAttr(resourceName, "tags.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "type", "ServiceNow"),
+				),
+			},
+			{
+				Config: testAccResourceConnectorYaml(id, updatedName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "id", id),
+					resource.TestCheckResourceAttr(resourceName, "name", updatedName),
+					resource.TestCheckResourceAttr(resourceName, "type", "ServiceNow"),
+				),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"yaml"},
+			},
+		},
+	})
+}
+
+// testAccConnectorYamlDestroy checks the connector is deleted without decoding it with the SDK, which
+// doesn't know all connector types.
+func testAccConnectorYamlDestroy(resourceName string) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		r := acctest.TestAccGetResource(resourceName, state)
+		session := acctest.TestAccGetApiClientFromProvider()
+
+		_, err := session.PlatformRequest(context.Background(), http.MethodGet, "/ng/api/connectors/"+r.Primary.ID, url.Values{}, nil, nil)
+		if err == nil {
+			return fmt.Errorf("Found connector: %s", r.Primary.ID)
+		}
+
+		return nil
+	}
+}
+
+func testAccResourceConnectorYaml(id string, name string) string {
+	return fmt.Sprintf(`
+		resource "harness_platform_connector_yaml" "test" {
+			identifier = "%[1]s"
+			name = "%[2]s"
+			description = "test"
+			tags = ["foo:bar"]
+
+			yaml = <<-EOT
+				connector:
+				  type: ServiceNow
+				  spec:
+				    serviceNowUrl: https://servicenow.com
+				    delegateSelectors:
+				      - harness-delegate
+				    auth:
+				      type: UsernamePassword
+				      spec:
+				        username: admin
+				        passwordRef: account.TEST_aws_secret_key
+			EOT
+		}
+`, id, name)
+}
@@ -39,7 +39,7 @@ func ResourceConnectorDynatrace() *schema.Resource {
 		},
 	}
 
-	helpers.SetMultiLevelResourceSchema(resource.Schema)
+	setConnectorResourceSchema(resource)
 
 	return resource
 }
@@ -50,6 +50,10 @@ func resourceConnectorDynatraceRead(ctx context.Context, d *schema.ResourceData,
 		return err
 	}
 
+	if conn == nil {
+		return nil
+	}
+
 	if err := readConnectorDynatrace(d, conn); err != nil {
 		return diag.FromErr(err)
 	}
@@ -70,7 +70,7 @@ func ResourceConnectorGcp() *schema.Resource {
 		},
 	}
 
-	helpers.SetMultiLevelResourceSchema(resource.Schema)
+	setConnectorResourceSchema(resource)
 
 	return resource
 }
@@ -81,6 +81,10 @@ func resourceConnectorGcpRead(ctx context.Context, d *schema.ResourceData, meta
 		return err
 	}
 
+	if conn == nil {
+		return nil
+	}
+
 	if err := readConnectorGcp(d, conn); err != nil {
 		return diag.FromErr(err)
 	}
@@ -39,7 +39,7 @@ func ResourceConnectorGCPSecretManager() *schema.Resource {
 		},
 	}
 
-	helpers.SetMultiLevelResourceSchema(resource.Schema)
+	setConnectorResourceSchema(resource)
 
 	return resource
 }
@@ -50,6 +50,10 @@ func resourceConnectorGcpSMRead(ctx context.Context, d *schema.ResourceData, met
 		return err
 	}
 
+	if conn == nil {
+		return nil
+	}
+
 	if err := readConnectorGcpSM(d, conn); err != nil {
 		return diag.FromErr(err)
 	}
@@ -130,7 +130,7 @@ func ResourceConnectorGitlab() *schema.Resource {
 		},
 	}
 
-	helpers.SetMultiLevelResourceSchema(resource.Schema)
+	setConnectorResourceSchema(resource)
 
 	return resource
 }
@@ -141,6 +141,10 @@ func resourceConnectorGitlabRead(ctx context.Context, d *schema.ResourceData, me
 		return err
 	}
 
+	if conn == nil {
+		return nil
+	}
+
 	if err := readConnectorGitlab(d, conn); err != nil {
 		return diag.FromErr(err)
 	}
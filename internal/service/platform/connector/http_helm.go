@@ -64,7 +64,7 @@ func ResourceConnectorHelm() *schema.Resource {
 		},
 	}
 
-	helpers.SetMultiLevelResourceSchema(resource.Schema)
+	setConnectorResourceSchema(resource)
 
 	return resource
 }
@@ -75,6 +75,10 @@ func resourceConnectorHelmRead(ctx context.Context, d *schema.ResourceData, meta
 		return err
 	}
 
+	if conn == nil {
+		return nil
+	}
+
 	if err := readConnectorHelm(d, conn); err != nil {
 		return diag.FromErr(err)
 	}
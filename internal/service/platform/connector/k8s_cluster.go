@@ -276,7 +276,7 @@ func ResourceConnectorK8s() *schema.Resource {
 		},
 	}
 
-	helpers.SetMultiLevelResourceSchema(resource.Schema)
+	setConnectorResourceSchema(resource)
 
 	return resource
 }
@@ -287,6 +287,10 @@ func resourceConnectorK8sRead(ctx context.Context, d *schema.ResourceData, meta
 		return err
 	}
 
+	if conn == nil {
+		return nil
+	}
+
 	if err := readConnectorK8s(d, conn); err != nil {
 		return diag.FromErr(err)
 	}
@@ -70,7 +70,7 @@ func ResourceConnectorNexus() *schema.Resource {
 		},
 	}
 
-	helpers.SetMultiLevelResourceSchema(resource.Schema)
+	setConnectorResourceSchema(resource)
 
 	return resource
 }
@@ -81,6 +81,10 @@ func resourceConnectorNexusRead(ctx context.Context, d *schema.ResourceData, met
 		return err
 	}
 
+	if conn == nil {
+		return nil
+	}
+
 	if err := readConnectorNexus(d, conn); err != nil {
 		return diag.FromErr(err)
 	}
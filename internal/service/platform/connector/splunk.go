@@ -49,7 +49,7 @@ func ResourceConnectorSplunk() *schema.Resource {
 		},
 	}
 
-	helpers.SetMultiLevelResourceSchema(resource.Schema)
+	setConnectorResourceSchema(resource)
 
 	return resource
 }
@@ -60,6 +60,10 @@ func resourceConnectorSplunkRead(ctx context.Context, d *schema.ResourceData, me
 		return err
 	}
 
+	if conn == nil {
+		return nil
+	}
+
 	if err := readConnectorSplunk(d, conn); err != nil {
 		return diag.FromErr(err)
 	}
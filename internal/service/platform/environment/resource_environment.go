@@ -23,7 +23,7 @@ func ResourceEnvironment() *schema.Resource {
 		UpdateContext: resourceEnvironmentCreateOrUpdate,
 		DeleteContext: resourceEnvironmentDelete,
 		CreateContext: resourceEnvironmentCreateOrUpdate,
-		Importer:      helpers.ProjectResourceImporter,
+		Importer:      helpers.ProjectResourceImportIdParser.WithLookupByName(lookupEnvironmentByName).Importer(),
 
 		Schema: map[string]*schema.Schema{
 			"color": {
@@ -39,9 +39,10 @@ func ResourceEnvironment() *schema.Resource {
 				ValidateFunc: validation.StringInSlice(nextgen.EnvironmentTypeValues, false),
 			},
 			"yaml": {
-				Description: "Environment YAML",
-				Type:        schema.TypeString,
-				Optional:    true,
+				Description:      "Environment YAML",
+				Type:             schema.TypeString,
+				Optional:         true,
+				DiffSuppressFunc: helpers.YamlDiffSuppressFunc,
 			},
 		},
 	}
@@ -60,7 +61,7 @@ func resourceEnvironmentRead(ctx context.Context, d *schema.ResourceData, meta i
 	})
 
 	if err != nil {
-		return helpers.HandleApiError(err, d, httpResp)
+		return helpers.HandleReadApiError(err, d, httpResp)
 	}
 
 	// Soft delete lookup error handling
@@ -146,3 +147,20 @@ func readEnvironment(d *schema.ResourceData, env *nextgen.EnvironmentResponseDet
 		d.Set("yaml", env.Yaml)
 	}
 }
+
+func lookupEnvironmentByName(ctx context.Context, d *schema.ResourceData, meta interface{}, name string) (string, error) {
+	c, ctx := meta.(*internal.Session).GetPlatformClientWithContext(ctx)
+
+	env, _, err := c.EnvironmentsApi.GetEnvironmentByName(ctx, c.AccountId, name, nextgen.GetEnvironmentByNameOpts{
+		OrgIdentifier:     optional.NewString(d.Get("org_id").(string)),
+		ProjectIdentifier: optional.NewString(d.Get("project_id").(string)),
+	})
+	if err != nil {
+		return "", fmt.Errorf("error looking up environment %q: %s", name, err)
+	}
+	if env == nil {
+		return "", fmt.Errorf("no environment named %q was found in project %s/%s", name, d.Get("org_id"), d.Get("project_id"))
+	}
+
+	return env.Identifier, nil
+}
@@ -86,7 +86,7 @@ func resourceEnvironmentClustersMappingRead(ctx context.Context, d *schema.Resou
 	})
 
 	if err != nil {
-		return helpers.HandleApiError(err, d, httpResp)
+		return helpers.HandleReadApiError(err, d, httpResp)
 	}
 
 	// Soft delete lookup error handling
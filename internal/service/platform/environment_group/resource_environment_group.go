@@ -46,9 +46,10 @@ func ResourceEnvironmentGroup() *schema.Resource {
 				Computed:    true,
 			},
 			"yaml": {
-				Description: "Env group YAML",
-				Type:        schema.TypeString,
-				Required:    true,
+				Description:      "Env group YAML",
+				Type:             schema.TypeString,
+				Required:         true,
+				DiffSuppressFunc: helpers.YamlDiffSuppressFunc,
 			},
 		},
 	}
@@ -80,7 +81,7 @@ func resourceEnvironmentGroupRead(ctx context.Context, d *schema.ResourceData, m
 	}
 
 	if err != nil {
-		return helpers.HandleApiError(err, d, httpResp)
+		return helpers.HandleReadApiError(err, d, httpResp)
 	}
 
 	// Soft delete lookup error handling
@@ -39,9 +39,10 @@ func ResourceEnvironmentServiceOverrides() *schema.Resource {
 				Required:    true,
 			},
 			"yaml": {
-				Description: "Environment Service Overrides YAML",
-				Type:        schema.TypeString,
-				Required:    true,
+				Description:      "Environment Service Overrides YAML",
+				Type:             schema.TypeString,
+				Required:         true,
+				DiffSuppressFunc: helpers.YamlDiffSuppressFunc,
 			},
 		},
 	}
@@ -63,7 +64,7 @@ func resourceEnvironmentServiceOverridesRead(ctx context.Context, d *schema.Reso
 			ServiceIdentifier: helpers.BuildField(d, "service_id"),
 		})
 	if err != nil {
-		return helpers.HandleApiError(err, d, httpResp)
+		return helpers.HandleReadApiError(err, d, httpResp)
 	}
 
 	// Soft delete lookup error handling
@@ -41,9 +41,10 @@ func ResourceInfrastructure() *schema.Resource {
 				Required:    true,
 			},
 			"yaml": {
-				Description: "Infrastructure YAML",
-				Type:        schema.TypeString,
-				Required:    true,
+				Description:      "Infrastructure YAML",
+				Type:             schema.TypeString,
+				Required:         true,
+				DiffSuppressFunc: helpers.YamlDiffSuppressFunc,
 			},
 			"deployment_type": {
 				Description: fmt.Sprintf("Infrastructure deployment type. Valid values are %s.", strings.Join(nextgen.InfrastructureDeploymentypeValues, ", ")),
@@ -68,7 +69,7 @@ func resourceInfrastructureRead(ctx context.Context, d *schema.ResourceData, met
 	})
 
 	if err != nil {
-		return helpers.HandleApiError(err, d, httpResp)
+		return helpers.HandleReadApiError(err, d, httpResp)
 	}
 
 	// Soft delete lookup error handling
@@ -20,6 +20,13 @@ func ResourceInputSet() *schema.Resource {
 		CreateContext: resourceInputSetCreateOrUpdate,
 		DeleteContext: resourceInputSetDelete,
 		Importer:      helpers.PipelineResourceImporter,
+		CustomizeDiff: helpers.YamlConsistencyCustomizeDiff("inputSet", map[string]string{
+			"identifier":  "identifier",
+			"name":        "name",
+			"org_id":      "orgIdentifier",
+			"project_id":  "projectIdentifier",
+			"pipeline_id": "pipeline.identifier",
+		}),
 
 		Schema: map[string]*schema.Schema{
 			"pipeline_id": {
@@ -28,9 +35,10 @@ func ResourceInputSet() *schema.Resource {
 				Required:    true,
 			},
 			"yaml": {
-				Description: "Input Set YAML",
-				Type:        schema.TypeString,
-				Required:    true,
+				Description:      "Input Set YAML",
+				Type:             schema.TypeString,
+				Required:         true,
+				DiffSuppressFunc: helpers.YamlDiffSuppressFunc,
 			},
 		},
 	}
@@ -53,7 +61,7 @@ func resourceInputSetRead(ctx context.Context, d *schema.ResourceData, meta inte
 	resp, httpResp, err := c.InputSetsApi.GetInputSet(ctx, id, c.AccountId, orgId, projectId, pipelineId, &nextgen.InputSetsApiGetInputSetOpts{})
 
 	if err != nil {
-		return helpers.HandleApiError(err, d, httpResp)
+		return helpers.HandleReadApiError(err, d, httpResp)
 	}
 
 	if resp.Data == nil {
@@ -2,6 +2,7 @@ package organization
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 
 	"github.com/harness/harness-go-sdk/harness/nextgen"
@@ -19,9 +20,7 @@ func ResourceOrganization() *schema.Resource {
 		UpdateContext: resourceOrganizationCreateOrUpdate,
 		DeleteContext: resourceOrganizationDelete,
 		CreateContext: resourceOrganizationCreateOrUpdate,
-		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
-		},
+		Importer:      helpers.AccountResourceImportIdParser.WithLookupByName(lookupOrganizationByName).Importer(),
 
 		Schema: map[string]*schema.Schema{},
 	}
@@ -43,7 +42,7 @@ func resourceOrganizationRead(ctx context.Context, d *schema.ResourceData, meta
 	resp, httpResp, err := c.OrganizationApi.GetOrganization(ctx, d.Id(), c.AccountId)
 
 	if err != nil {
-		return helpers.HandleApiError(err, d, httpResp)
+		return helpers.HandleReadApiError(err, d, httpResp)
 	}
 
 	readOrganization(d, resp.Data.Organization)
@@ -103,3 +102,17 @@ func readOrganization(d *schema.ResourceData, org *nextgen.Organization) {
 	d.Set("description", org.Description)
 	d.Set("tags", helpers.FlattenTags(org.Tags))
 }
+
+func lookupOrganizationByName(ctx context.Context, d *schema.ResourceData, meta interface{}, name string) (string, error) {
+	c, ctx := meta.(*internal.Session).GetPlatformClientWithContext(ctx)
+
+	org, _, err := c.OrganizationApi.GetOrganizationByName(ctx, c.AccountId, name)
+	if err != nil {
+		return "", fmt.Errorf("error looking up organization %q: %s", name, err)
+	}
+	if org == nil || org.Organization == nil {
+		return "", fmt.Errorf("no organization named %q was found", name)
+	}
+
+	return org.Organization.Identifier, nil
+}
@@ -2,12 +2,14 @@ package pipeline
 
 import (
 	"context"
+	"log"
 	"net/http"
 
 	"github.com/harness/harness-go-sdk/harness/nextgen"
 	"github.com/harness/terraform-provider-harness/helpers"
 	"github.com/harness/terraform-provider-harness/internal"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -20,13 +22,29 @@ func ResourcePipeline() *schema.Resource {
 		DeleteContext: resourcePipelineDelete,
 		CreateContext: resourcePipelineCreateOrUpdate,
 		Importer:      helpers.ProjectResourceImporter,
+		CustomizeDiff: customdiff.Sequence(
+			pipelineStructuredCustomizeDiff,
+			helpers.YamlConsistencyCustomizeDiff("pipeline", map[string]string{
+				"identifier": "identifier",
+				"name":       "name",
+				"org_id":     "orgIdentifier",
+				"project_id": "projectIdentifier",
+			}),
+		),
 
 		Schema: map[string]*schema.Schema{
 			"yaml": {
-				Description: "YAML of the pipeline.",
-				Type:        schema.TypeString,
-				Required:    true,
+				Description:      "YAML of the pipeline. Use this or `stage` to define the pipeline. When the pipeline is defined with `stage` blocks this is the YAML rendered from them.",
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				ExactlyOneOf:     []string{"yaml", "stage"},
+				DiffSuppressFunc: helpers.YamlDiffSuppressFunc,
 			},
+			"stage":              getStageSchema(),
+			"variable":           getVariableSchema(),
+			"properties":         getPropertiesSchema(),
+			"notification_rules": getNotificationRulesSchema(),
 		},
 	}
 
@@ -49,7 +67,7 @@ func resourcePipelineRead(ctx context.Context, d *schema.ResourceData, meta inte
 	)
 
 	if err != nil {
-		return helpers.HandleApiError(err, d, httpResp)
+		return helpers.HandleReadApiError(err, d, httpResp)
 	}
 
 	readPipeline(d, resp.Data)
@@ -60,10 +78,12 @@ func resourcePipelineRead(ctx context.Context, d *schema.ResourceData, meta inte
 func resourcePipelineCreateOrUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	c, ctx := meta.(*internal.Session).GetPlatformClientWithContext(ctx)
 
-	var err error
 	var httpResp *http.Response
 	id := d.Id()
-	pipeline := buildPipeline(d)
+	pipeline, err := buildPipeline(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
 	if id == "" {
 		_, httpResp, err = c.PipelinesApi.PostPipeline(ctx, pipeline.Yaml, c.AccountId, pipeline.OrgIdentifier, pipeline.ProjectIdentifier, &nextgen.PipelinesApiPostPipelineOpts{})
@@ -89,7 +109,10 @@ func resourcePipelineCreateOrUpdate(ctx context.Context, d *schema.ResourceData,
 func resourcePipelineDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	c, ctx := meta.(*internal.Session).GetPlatformClientWithContext(ctx)
 
-	pipeline := buildPipeline(d)
+	pipeline, err := buildPipeline(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
 	_, httpResp, err := c.PipelinesApi.DeletePipeline(ctx, c.AccountId, pipeline.OrgIdentifier, pipeline.ProjectIdentifier, pipeline.Identifier, &nextgen.PipelinesApiDeletePipelineOpts{})
 	if err != nil {
@@ -99,15 +122,23 @@ func resourcePipelineDelete(ctx context.Context, d *schema.ResourceData, meta in
 	return nil
 }
 
-// Build PipelineYAML object from stored pipeline yaml
-func buildPipeline(d *schema.ResourceData) *nextgen.Pipeline {
+// Build PipelineYAML object from stored pipeline yaml, or render it from the stages
+func buildPipeline(d *schema.ResourceData) (*nextgen.Pipeline, error) {
+	yaml := d.Get("yaml").(string)
+	if isStructuredPipeline(d.GetRawConfig(), yaml) {
+		var err error
+		if yaml, err = renderPipelineYaml(d); err != nil {
+			return nil, err
+		}
+	}
+
 	return &nextgen.Pipeline{
 		Identifier:        d.Get("identifier").(string),
 		Name:              d.Get("name").(string),
 		OrgIdentifier:     d.Get("org_id").(string),
 		ProjectIdentifier: d.Get("project_id").(string),
-		Yaml:              d.Get("yaml").(string),
-	}
+		Yaml:              yaml,
+	}, nil
 }
 
 // Read response from API out to the stored identifiers
@@ -118,4 +149,8 @@ func readPipeline(d *schema.ResourceData, pipeline *nextgen.PmsPipelineResponse)
 	d.Set("org_id", pipeline.PipelineData.Pipeline.OrgIdentifier)
 	d.Set("project_id", pipeline.PipelineData.Pipeline.ProjectIdentifier)
 	d.Set("yaml", pipeline.YamlPipeline)
+
+	if err := flattenPipelineYaml(d, pipeline.YamlPipeline); err != nil {
+		log.Printf("[WARN] %s", err)
+	}
 }
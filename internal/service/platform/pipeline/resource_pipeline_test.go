@@ -47,6 +47,46 @@ func TestAccResourcePipeline(t *testing.T) {
 	})
 }
 
+func TestAccResourcePipeline_Structured(t *testing.T) {
+	id := fmt.Sprintf("%s_%s", t.Name(), utils.RandStringBytes(6))
+	name := id
+	updatedName := fmt.Sprintf("%s_updated", id)
+
+	resourceName := "harness_platform_pipeline.test"
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { acctest.TestAccPreCheck(t) },
+		ProviderFactories: acctest.ProviderFactories,
+		CheckDestroy:      testAccPipelineDestroy(resourceName),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourcePipelineStructured(id, name),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "id", id),
+					resource.TestCheckResourceAttr(resourceName, "name", name),
+					resource.TestCheckResourceAttr(resourceName, "stage.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "stage.0.step.0.identifier", "echo"),
+					resource.TestCheckResourceAttr(resourceName, "variable.0.name", "greeting"),
+					resource.TestCheckResourceAttrSet(resourceName, "yaml"),
+				),
+			},
+			{
+				Config: testAccResourcePipelineStructured(id, updatedName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "id", id),
+					resource.TestCheckResourceAttr(resourceName, "name", updatedName),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: acctest.ProjectResourceImportStateIdFunc(resourceName),
+			},
+		},
+	})
+}
+
 func testAccGetPipeline(resourceName string, state *terraform.State) (*nextgen.PmsPipelineResponse, error) {
 	r := acctest.TestAccGetResource(resourceName, state)
 	c, ctx := acctest.TestAccGetPlatformClientWithContext()
@@ -182,3 +222,54 @@ func testAccResourcePipeline(id string, name string) string {
         }
         `, id, name)
 }
+
+func testAccResourcePipelineStructured(id string, name string) string {
+	return fmt.Sprintf(`
+		resource "harness_platform_organization" "test" {
+			identifier = "%[1]s"
+			name = "%[2]s"
+		}
+
+		resource "harness_platform_project" "test" {
+			identifier = "%[1]s"
+			name = "%[2]s"
+			org_id = harness_platform_organization.test.id
+			color = "#472848"
+		}
+
+		resource "harness_platform_pipeline" "test" {
+			identifier = "%[1]s"
+			org_id = harness_platform_project.test.org_id
+			project_id = harness_platform_project.test.id
+			name = "%[2]s"
+
+			stage {
+				identifier = "greet"
+				name = "greet"
+				type = "Custom"
+
+				step {
+					identifier = "echo"
+					name = "echo"
+					type = "ShellScript"
+					timeout = "10m"
+					spec = yamlencode({
+						shell = "Bash"
+						onDelegate = true
+						source = {
+							type = "Inline"
+							spec = {
+								script = "echo <+pipeline.variables.greeting>"
+							}
+						}
+					})
+				}
+			}
+
+			variable {
+				name = "greeting"
+				value = "hello"
+			}
+		}
+	`, id, name)
+}
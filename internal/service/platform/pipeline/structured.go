@@ -0,0 +1,790 @@
+package pipeline
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/harness/terraform-provider-harness/helpers"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"gopkg.in/yaml.v3"
+)
+
+// structuredAttributes are the attributes of the structured pipeline definition, which is rendered
+// into the pipeline YAML.
+var structuredAttributes = []string{"stage", "variable", "properties", "notification_rules"}
+
+func getStageSchema() *schema.Schema {
+	return &schema.Schema{
+		Description:  "Stages of the pipeline, in the order they run. Use this or `yaml` to define the pipeline.",
+		Type:         schema.TypeList,
+		Optional:     true,
+		Computed:     true,
+		ExactlyOneOf: []string{"yaml", "stage"},
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"identifier": {
+					Description: "Unique identifier of the stage.",
+					Type:        schema.TypeString,
+					Required:    true,
+				},
+				"name": {
+					Description: "Name of the stage.",
+					Type:        schema.TypeString,
+					Required:    true,
+				},
+				"description": {
+					Description: "Description of the stage.",
+					Type:        schema.TypeString,
+					Optional:    true,
+				},
+				"type": {
+					Description: "Type of the stage, e.g. `Deployment`, `CI` or `Approval`.",
+					Type:        schema.TypeString,
+					Required:    true,
+				},
+				"spec": {
+					Description:      "YAML of the stage `spec`. The `execution` is rendered from the `step` and `step_group` blocks when they are set.",
+					Type:             schema.TypeString,
+					Optional:         true,
+					ValidateFunc:     validateYaml,
+					DiffSuppressFunc: helpers.YamlDiffSuppressFunc,
+				},
+				"failure_strategies": {
+					Description:      "YAML of the `failureStrategies` of the stage.",
+					Type:             schema.TypeString,
+					Optional:         true,
+					ValidateFunc:     validateYaml,
+					DiffSuppressFunc: helpers.YamlDiffSuppressFunc,
+				},
+				"step": getStepSchema("Steps of the stage execution. They run before the step groups."),
+				"step_group": {
+					Description: "Step groups of the stage execution. They run after the steps.",
+					Type:        schema.TypeList,
+					Optional:    true,
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"identifier": {
+								Description: "Unique identifier of the step group.",
+								Type:        schema.TypeString,
+								Required:    true,
+							},
+							"name": {
+								Description: "Name of the step group.",
+								Type:        schema.TypeString,
+								Required:    true,
+							},
+							"step": getStepSchema("Steps of the step group."),
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func getStepSchema(description string) *schema.Schema {
+	return &schema.Schema{
+		Description: description,
+		Type:        schema.TypeList,
+		Optional:    true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"identifier": {
+					Description: "Unique identifier of the step.",
+					Type:        schema.TypeString,
+					Required:    true,
+				},
+				"name": {
+					Description: "Name of the step.",
+					Type:        schema.TypeString,
+					Required:    true,
+				},
+				"type": {
+					Description: "Type of the step, e.g. `Run` or `ShellScript`.",
+					Type:        schema.TypeString,
+					Required:    true,
+				},
+				"timeout": {
+					Description: "Timeout of the step, e.g. `10m`.",
+					Type:        schema.TypeString,
+					Optional:    true,
+				},
+				"spec": {
+					Description:      "YAML of the step `spec`.",
+					Type:             schema.TypeString,
+					Optional:         true,
+					ValidateFunc:     validateYaml,
+					DiffSuppressFunc: helpers.YamlDiffSuppressFunc,
+				},
+			},
+		},
+	}
+}
+
+func getVariableSchema() *schema.Schema {
+	return &schema.Schema{
+		Description: "Variables of the pipeline. Only used with `stage`.",
+		Type:        schema.TypeList,
+		Optional:    true,
+		Computed:    true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"name": {
+					Description: "Name of the variable.",
+					Type:        schema.TypeString,
+					Required:    true,
+				},
+				"type": {
+					Description: "Type of the variable, `String`, `Number` or `Secret`.",
+					Type:        schema.TypeString,
+					Optional:    true,
+					Default:     "String",
+				},
+				"value": {
+					Description: "Value of the variable, which can be a runtime input such as `<+input>`.",
+					Type:        schema.TypeString,
+					Optional:    true,
+				},
+				"description": {
+					Description: "Description of the variable.",
+					Type:        schema.TypeString,
+					Optional:    true,
+				},
+			},
+		},
+	}
+}
+
+func getPropertiesSchema() *schema.Schema {
+	return &schema.Schema{
+		Description: "Properties of the pipeline. Only used with `stage`.",
+		Type:        schema.TypeList,
+		Optional:    true,
+		Computed:    true,
+		MaxItems:    1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"ci_codebase": {
+					Description: "Codebase cloned by the CI stages.",
+					Type:        schema.TypeList,
+					Required:    true,
+					MaxItems:    1,
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"connector_ref": {
+								Description: "Reference to the git connector of the codebase.",
+								Type:        schema.TypeString,
+								Required:    true,
+							},
+							"repo_name": {
+								Description: "Name of the repository, when the connector is for an account.",
+								Type:        schema.TypeString,
+								Optional:    true,
+							},
+							"build": {
+								Description:      "YAML of the `build` to clone, e.g. `<+input>`.",
+								Type:             schema.TypeString,
+								Optional:         true,
+								ValidateFunc:     validateYaml,
+								DiffSuppressFunc: helpers.YamlDiffSuppressFunc,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func getNotificationRulesSchema() *schema.Schema {
+	return &schema.Schema{
+		Description: "Notification rules of the pipeline. Only used with `stage`.",
+		Type:        schema.TypeList,
+		Optional:    true,
+		Computed:    true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"identifier": {
+					Description: "Unique identifier of the notification rule.",
+					Type:        schema.TypeString,
+					Required:    true,
+				},
+				"name": {
+					Description: "Name of the notification rule.",
+					Type:        schema.TypeString,
+					Required:    true,
+				},
+				"enabled": {
+					Description: "Whether the notification rule is enabled.",
+					Type:        schema.TypeBool,
+					Optional:    true,
+					Default:     true,
+				},
+				"pipeline_events": {
+					Description: "Pipeline events that send a notification, e.g. `AllEvents`, `PipelineSuccess` or `PipelineFailed`.",
+					Type:        schema.TypeList,
+					Required:    true,
+					Elem: &schema.Schema{
+						Type: schema.TypeString,
+					},
+				},
+				"notification_method_type": {
+					Description: "Type of the notification method, e.g. `Email`, `Slack` or `MsTeams`.",
+					Type:        schema.TypeString,
+					Required:    true,
+				},
+				"notification_method_spec": {
+					Description:      "YAML of the `spec` of the notification method.",
+					Type:             schema.TypeString,
+					Optional:         true,
+					ValidateFunc:     validateYaml,
+					DiffSuppressFunc: helpers.YamlDiffSuppressFunc,
+				},
+			},
+		},
+	}
+}
+
+func validateYaml(i interface{}, k string) ([]string, []error) {
+	if _, err := parseYamlNode(i.(string)); err != nil {
+		return nil, []error{fmt.Errorf("%s must be valid YAML: %s", k, err)}
+	}
+	return nil, nil
+}
+
+// The pipeline YAML modelled by the structured definition. Parts that are only ever passed through,
+// such as the stage and step specs, are kept as YAML nodes.
+type pipelineDocument struct {
+	Pipeline pipelineDefinition `yaml:"pipeline"`
+}
+
+type pipelineDefinition struct {
+	Name              string                     `yaml:"name"`
+	Identifier        string                     `yaml:"identifier"`
+	ProjectIdentifier string                     `yaml:"projectIdentifier"`
+	OrgIdentifier     string                     `yaml:"orgIdentifier"`
+	Description       string                     `yaml:"description,omitempty"`
+	Tags              map[string]string          `yaml:"tags,omitempty"`
+	Properties        *pipelineProperties        `yaml:"properties,omitempty"`
+	Stages            []pipelineStageElement     `yaml:"stages"`
+	Variables         []pipelineVariable         `yaml:"variables,omitempty"`
+	NotificationRules []pipelineNotificationRule `yaml:"notificationRules,omitempty"`
+}
+
+type pipelineProperties struct {
+	Ci *pipelineCiProperties `yaml:"ci,omitempty"`
+}
+
+type pipelineCiProperties struct {
+	Codebase *pipelineCodebase `yaml:"codebase,omitempty"`
+}
+
+type pipelineCodebase struct {
+	ConnectorRef string    `yaml:"connectorRef"`
+	RepoName     string    `yaml:"repoName,omitempty"`
+	Build        yaml.Node `yaml:"build,omitempty"`
+}
+
+type pipelineStageElement struct {
+	Stage *pipelineStage `yaml:"stage,omitempty"`
+}
+
+type pipelineStage struct {
+	Name              string    `yaml:"name"`
+	Identifier        string    `yaml:"identifier"`
+	Description       string    `yaml:"description,omitempty"`
+	Type              string    `yaml:"type"`
+	Spec              yaml.Node `yaml:"spec,omitempty"`
+	FailureStrategies yaml.Node `yaml:"failureStrategies,omitempty"`
+}
+
+type pipelineExecution struct {
+	Steps []pipelineStepElement `yaml:"steps"`
+}
+
+type pipelineStepElement struct {
+	Step      *pipelineStep      `yaml:"step,omitempty"`
+	StepGroup *pipelineStepGroup `yaml:"stepGroup,omitempty"`
+}
+
+type pipelineStep struct {
+	Name       string    `yaml:"name"`
+	Identifier string    `yaml:"identifier"`
+	Type       string    `yaml:"type"`
+	Timeout    string    `yaml:"timeout,omitempty"`
+	Spec       yaml.Node `yaml:"spec,omitempty"`
+}
+
+type pipelineStepGroup struct {
+	Name       string                `yaml:"name"`
+	Identifier string                `yaml:"identifier"`
+	Steps      []pipelineStepElement `yaml:"steps"`
+}
+
+type pipelineVariable struct {
+	Name        string `yaml:"name"`
+	Type        string `yaml:"type"`
+	Description string `yaml:"description,omitempty"`
+	Value       string `yaml:"value"`
+}
+
+type pipelineNotificationRule struct {
+	Name               string                     `yaml:"name"`
+	Identifier         string                     `yaml:"identifier"`
+	PipelineEvents     []pipelineEvent            `yaml:"pipelineEvents"`
+	NotificationMethod pipelineNotificationMethod `yaml:"notificationMethod"`
+	Enabled            bool                       `yaml:"enabled"`
+}
+
+type pipelineEvent struct {
+	Type string `yaml:"type"`
+}
+
+type pipelineNotificationMethod struct {
+	Type string    `yaml:"type"`
+	Spec yaml.Node `yaml:"spec,omitempty"`
+}
+
+// isStructuredPipeline reports whether the pipeline is defined with `stage` blocks rather than `yaml`.
+// The configuration isn't available everywhere, e.g. when deleting, in which case the structured form
+// is assumed when there is no YAML to fall back to.
+func isStructuredPipeline(config cty.Value, yaml string) bool {
+	if !config.IsNull() && config.Type().IsObjectType() && config.Type().HasAttribute("stage") {
+		stages := config.GetAttr("stage")
+		return !stages.IsNull() && (!stages.IsKnown() || stages.LengthInt() > 0)
+	}
+	return yaml == ""
+}
+
+// pipelineStructuredCustomizeDiff keeps `yaml` and the structured definition in step: the YAML is
+// rendered by the provider when the structured definition changes and the structured definition is
+// read back when the YAML changes.
+func pipelineStructuredCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !isStructuredPipeline(d.GetRawConfig(), d.Get("yaml").(string)) {
+		if d.HasChange("yaml") {
+			for _, attr := range structuredAttributes {
+				if err := d.SetNewComputed(attr); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+
+	// The optional blocks are also computed, so removing them from the configuration doesn't
+	// remove them from the plan.
+	config := d.GetRawConfig()
+	for _, attr := range structuredAttributes[1:] {
+		if config.IsNull() {
+			break
+		}
+		value := config.GetAttr(attr)
+		if value.IsKnown() && (value.IsNull() || value.LengthInt() == 0) && len(d.Get(attr).([]interface{})) > 0 {
+			if err := d.SetNew(attr, []interface{}{}); err != nil {
+				return err
+			}
+		}
+	}
+
+	if d.Id() == "" || d.HasChanges(append([]string{"identifier", "name", "description", "org_id", "project_id", "tags"}, structuredAttributes...)...) {
+		return d.SetNewComputed("yaml")
+	}
+	return nil
+}
+
+// renderPipelineYaml renders the pipeline YAML from the structured definition.
+func renderPipelineYaml(d *schema.ResourceData) (string, error) {
+	return renderPipeline(pipelineValues{
+		identifier:        d.Get("identifier").(string),
+		name:              d.Get("name").(string),
+		description:       d.Get("description").(string),
+		orgIdentifier:     d.Get("org_id").(string),
+		projectIdentifier: d.Get("project_id").(string),
+		tags:              helpers.ExpandTags(d.Get("tags").(*schema.Set).List()),
+		stages:            d.Get("stage").([]interface{}),
+		variables:         d.Get("variable").([]interface{}),
+		properties:        d.Get("properties").([]interface{}),
+		notificationRules: d.Get("notification_rules").([]interface{}),
+	})
+}
+
+// pipelineValues holds the attribute values a pipeline YAML is rendered from.
+type pipelineValues struct {
+	identifier        string
+	name              string
+	description       string
+	orgIdentifier     string
+	projectIdentifier string
+	tags              map[string]string
+	stages            []interface{}
+	variables         []interface{}
+	properties        []interface{}
+	notificationRules []interface{}
+}
+
+func renderPipeline(v pipelineValues) (string, error) {
+	pipeline := pipelineDefinition{
+		Name:              v.name,
+		Identifier:        v.identifier,
+		Description:       v.description,
+		OrgIdentifier:     v.orgIdentifier,
+		ProjectIdentifier: v.projectIdentifier,
+		Tags:              v.tags,
+		Stages:            []pipelineStageElement{},
+	}
+
+	for _, s := range v.stages {
+		stage, err := expandStage(s.(map[string]interface{}))
+		if err != nil {
+			return "", err
+		}
+		pipeline.Stages = append(pipeline.Stages, pipelineStageElement{Stage: stage})
+	}
+
+	for _, v := range v.variables {
+		variable := v.(map[string]interface{})
+		pipeline.Variables = append(pipeline.Variables, pipelineVariable{
+			Name:        variable["name"].(string),
+			Type:        variable["type"].(string),
+			Description: variable["description"].(string),
+			Value:       variable["value"].(string),
+		})
+	}
+
+	if len(v.properties) > 0 && v.properties[0] != nil {
+		codebases := v.properties[0].(map[string]interface{})["ci_codebase"].([]interface{})
+		if len(codebases) > 0 && codebases[0] != nil {
+			codebase := codebases[0].(map[string]interface{})
+			build, err := parseYamlNode(codebase["build"].(string))
+			if err != nil {
+				return "", fmt.Errorf("invalid build of the ci codebase: %s", err)
+			}
+			pipeline.Properties = &pipelineProperties{Ci: &pipelineCiProperties{Codebase: &pipelineCodebase{
+				ConnectorRef: codebase["connector_ref"].(string),
+				RepoName:     codebase["repo_name"].(string),
+				Build:        build,
+			}}}
+		}
+	}
+
+	for _, r := range v.notificationRules {
+		rule := r.(map[string]interface{})
+		spec, err := parseYamlNode(rule["notification_method_spec"].(string))
+		if err != nil {
+			return "", fmt.Errorf("invalid notification method spec of notification rule %s: %s", rule["identifier"], err)
+		}
+		notificationRule := pipelineNotificationRule{
+			Name:               rule["name"].(string),
+			Identifier:         rule["identifier"].(string),
+			PipelineEvents:     []pipelineEvent{},
+			NotificationMethod: pipelineNotificationMethod{Type: rule["notification_method_type"].(string), Spec: spec},
+			Enabled:            rule["enabled"].(bool),
+		}
+		for _, event := range rule["pipeline_events"].([]interface{}) {
+			notificationRule.PipelineEvents = append(notificationRule.PipelineEvents, pipelineEvent{Type: event.(string)})
+		}
+		pipeline.NotificationRules = append(pipeline.NotificationRules, notificationRule)
+	}
+
+	out, err := yaml.Marshal(pipelineDocument{Pipeline: pipeline})
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
+
+func expandStage(s map[string]interface{}) (*pipelineStage, error) {
+	identifier := s["identifier"].(string)
+
+	spec, err := parseYamlNode(s["spec"].(string))
+	if err != nil {
+		return nil, fmt.Errorf("invalid spec of stage %s: %s", identifier, err)
+	}
+	failureStrategies, err := parseYamlNode(s["failure_strategies"].(string))
+	if err != nil {
+		return nil, fmt.Errorf("invalid failure strategies of stage %s: %s", identifier, err)
+	}
+
+	steps, err := expandSteps(s["step"].([]interface{}))
+	if err != nil {
+		return nil, fmt.Errorf("stage %s: %s", identifier, err)
+	}
+	for _, g := range s["step_group"].([]interface{}) {
+		group := g.(map[string]interface{})
+		groupSteps, err := expandSteps(group["step"].([]interface{}))
+		if err != nil {
+			return nil, fmt.Errorf("stage %s: %s", identifier, err)
+		}
+		steps = append(steps, pipelineStepElement{StepGroup: &pipelineStepGroup{
+			Name:       group["name"].(string),
+			Identifier: group["identifier"].(string),
+			Steps:      groupSteps,
+		}})
+	}
+
+	if len(steps) > 0 {
+		if spec.Kind == 0 {
+			spec = yaml.Node{Kind: yaml.MappingNode}
+		}
+		if spec.Kind != yaml.MappingNode {
+			return nil, fmt.Errorf("spec of stage %s must be a mapping", identifier)
+		}
+		if mappingValue(&spec, "execution") != nil {
+			return nil, fmt.Errorf("spec of stage %s must not contain an `execution` when `step` or `step_group` blocks are set", identifier)
+		}
+
+		execution := &yaml.Node{}
+		if err := execution.Encode(pipelineExecution{Steps: steps}); err != nil {
+			return nil, err
+		}
+		spec.Content = append(spec.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: "execution"}, execution)
+	}
+
+	return &pipelineStage{
+		Name:              s["name"].(string),
+		Identifier:        identifier,
+		Description:       s["description"].(string),
+		Type:              s["type"].(string),
+		Spec:              spec,
+		FailureStrategies: failureStrategies,
+	}, nil
+}
+
+func expandSteps(steps []interface{}) ([]pipelineStepElement, error) {
+	elements := []pipelineStepElement{}
+	for _, s := range steps {
+		step := s.(map[string]interface{})
+		spec, err := parseYamlNode(step["spec"].(string))
+		if err != nil {
+			return nil, fmt.Errorf("invalid spec of step %s: %s", step["identifier"], err)
+		}
+		elements = append(elements, pipelineStepElement{Step: &pipelineStep{
+			Name:       step["name"].(string),
+			Identifier: step["identifier"].(string),
+			Type:       step["type"].(string),
+			Timeout:    step["timeout"].(string),
+			Spec:       spec,
+		}})
+	}
+	return elements, nil
+}
+
+// flattenPipelineYaml sets the structured definition from the pipeline YAML. Pipelines using parts of
+// the YAML that the structured definition doesn't model are left to `yaml`, which is detected by
+// rendering the definition again and comparing it with the original.
+func flattenPipelineYaml(d *schema.ResourceData, pipelineYaml string) error {
+	values, err := parsePipeline(pipelineYaml)
+	if err != nil {
+		return err
+	}
+
+	rendered, err := renderPipeline(*values)
+	if err != nil {
+		return err
+	}
+	if !helpers.YamlEqual(pipelineYaml, rendered) {
+		return fmt.Errorf("pipeline %s uses parts of the pipeline YAML that can only be managed with `yaml`", values.identifier)
+	}
+
+	d.Set("stage", values.stages)
+	d.Set("variable", values.variables)
+	d.Set("properties", values.properties)
+	d.Set("notification_rules", values.notificationRules)
+	return nil
+}
+
+func parsePipeline(pipelineYaml string) (*pipelineValues, error) {
+	doc := &pipelineDocument{}
+	if err := yaml.Unmarshal([]byte(pipelineYaml), doc); err != nil {
+		return nil, err
+	}
+	pipeline := doc.Pipeline
+
+	values := &pipelineValues{
+		identifier:        pipeline.Identifier,
+		name:              pipeline.Name,
+		description:       pipeline.Description,
+		orgIdentifier:     pipeline.OrgIdentifier,
+		projectIdentifier: pipeline.ProjectIdentifier,
+		tags:              pipeline.Tags,
+		stages:            []interface{}{},
+		variables:         []interface{}{},
+		properties:        []interface{}{},
+		notificationRules: []interface{}{},
+	}
+
+	for _, element := range pipeline.Stages {
+		if element.Stage == nil {
+			return nil, fmt.Errorf("pipeline %s has parallel stages, which can only be managed with `yaml`", pipeline.Identifier)
+		}
+		values.stages = append(values.stages, flattenStage(element.Stage))
+	}
+
+	for _, variable := range pipeline.Variables {
+		values.variables = append(values.variables, map[string]interface{}{
+			"name":        variable.Name,
+			"type":        variable.Type,
+			"value":       variable.Value,
+			"description": variable.Description,
+		})
+	}
+
+	if pipeline.Properties != nil && pipeline.Properties.Ci != nil && pipeline.Properties.Ci.Codebase != nil {
+		codebase := pipeline.Properties.Ci.Codebase
+		values.properties = []interface{}{map[string]interface{}{
+			"ci_codebase": []interface{}{map[string]interface{}{
+				"connector_ref": codebase.ConnectorRef,
+				"repo_name":     codebase.RepoName,
+				"build":         yamlNodeString(&codebase.Build),
+			}},
+		}}
+	}
+
+	for _, rule := range pipeline.NotificationRules {
+		events := []interface{}{}
+		for _, event := range rule.PipelineEvents {
+			events = append(events, event.Type)
+		}
+		values.notificationRules = append(values.notificationRules, map[string]interface{}{
+			"identifier":               rule.Identifier,
+			"name":                     rule.Name,
+			"enabled":                  rule.Enabled,
+			"pipeline_events":          events,
+			"notification_method_type": rule.NotificationMethod.Type,
+			"notification_method_spec": yamlNodeString(&rule.NotificationMethod.Spec),
+		})
+	}
+
+	return values, nil
+}
+
+// flattenStage flattens a stage, moving its execution into `step` and `step_group` blocks when they
+// can represent it. Otherwise the execution is kept in the spec.
+func flattenStage(stage *pipelineStage) map[string]interface{} {
+	s := map[string]interface{}{
+		"identifier":         stage.Identifier,
+		"name":               stage.Name,
+		"description":        stage.Description,
+		"type":               stage.Type,
+		"spec":               yamlNodeString(&stage.Spec),
+		"failure_strategies": yamlNodeString(&stage.FailureStrategies),
+		"step":               []interface{}{},
+		"step_group":         []interface{}{},
+	}
+
+	if stage.Spec.Kind != yaml.MappingNode {
+		return s
+	}
+	executionNode := mappingValue(&stage.Spec, "execution")
+	if executionNode == nil {
+		return s
+	}
+
+	execution := pipelineExecution{}
+	if executionNode.Decode(&execution) != nil {
+		return s
+	}
+
+	spec := &yaml.Node{Kind: yaml.MappingNode, Tag: stage.Spec.Tag, Style: stage.Spec.Style}
+	for i := 0; i+1 < len(stage.Spec.Content); i += 2 {
+		if stage.Spec.Content[i].Value != "execution" {
+			spec.Content = append(spec.Content, stage.Spec.Content[i], stage.Spec.Content[i+1])
+		}
+	}
+
+	steps, groups := []interface{}{}, []interface{}{}
+	for _, element := range execution.Steps {
+		switch {
+		case element.Step != nil && element.StepGroup == nil && len(groups) == 0:
+			steps = append(steps, flattenStep(element.Step))
+		case element.StepGroup != nil && element.Step == nil:
+			groupSteps := []interface{}{}
+			for _, groupElement := range element.StepGroup.Steps {
+				if groupElement.Step == nil || groupElement.StepGroup != nil {
+					return s
+				}
+				groupSteps = append(groupSteps, flattenStep(groupElement.Step))
+			}
+			groups = append(groups, map[string]interface{}{
+				"identifier": element.StepGroup.Identifier,
+				"name":       element.StepGroup.Name,
+				"step":       groupSteps,
+			})
+		default:
+			// Steps after step groups and parallel steps can't be represented by the blocks.
+			return s
+		}
+	}
+
+	flattened := map[string]interface{}{}
+	for k, v := range s {
+		flattened[k] = v
+	}
+	flattened["spec"] = yamlNodeString(spec)
+	flattened["step"] = steps
+	flattened["step_group"] = groups
+
+	// Only use the blocks when they render back to the same stage, e.g. the execution has no rollback steps.
+	original, err := yaml.Marshal(stage)
+	if err != nil {
+		return s
+	}
+	rendered, err := expandStage(flattened)
+	if err != nil {
+		return s
+	}
+	if out, err := yaml.Marshal(rendered); err != nil || !helpers.YamlEqual(string(original), string(out)) {
+		return s
+	}
+	return flattened
+}
+
+func flattenStep(step *pipelineStep) map[string]interface{} {
+	return map[string]interface{}{
+		"identifier": step.Identifier,
+		"name":       step.Name,
+		"type":       step.Type,
+		"timeout":    step.Timeout,
+		"spec":       yamlNodeString(&step.Spec),
+	}
+}
+
+// parseYamlNode parses a YAML document into its root node, returning a zero node for an empty
+// document so it is omitted from the rendered YAML.
+func parseYamlNode(s string) (yaml.Node, error) {
+	if strings.TrimSpace(s) == "" {
+		return yaml.Node{}, nil
+	}
+
+	doc := yaml.Node{}
+	if err := yaml.Unmarshal([]byte(s), &doc); err != nil {
+		return yaml.Node{}, err
+	}
+	if doc.Kind != yaml.DocumentNode || len(doc.Content) == 0 {
+		return yaml.Node{}, nil
+	}
+	return *doc.Content[0], nil
+}
+
+// yamlNodeString renders a YAML node as a document, returning an empty string for empty nodes.
+func yamlNodeString(n *yaml.Node) string {
+	if n.Kind == 0 || (n.Kind == yaml.MappingNode || n.Kind == yaml.SequenceNode) && len(n.Content) == 0 {
+		return ""
+	}
+	out, err := yaml.Marshal(n)
+	if err != nil {
+		return ""
+	}
+	return string(out)
+}
+
+func mappingValue(n *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		if n.Content[i].Value == key {
+			return n.Content[i+1]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,165 @@
+package pipeline
+
+import (
+	"testing"
+
+	"github.com/harness/terraform-provider-harness/helpers"
+	"github.com/stretchr/testify/require"
+)
+
+const testStructuredPipelineYaml = `
+pipeline:
+    name: test
+    identifier: test
+    projectIdentifier: project
+    orgIdentifier: org
+    tags:
+        team: cd
+    properties:
+        ci:
+            codebase:
+                connectorRef: github
+                repoName: app
+                build: <+input>
+    stages:
+        - stage:
+            name: build
+            identifier: build
+            type: CI
+            spec:
+                cloneCodebase: true
+                execution:
+                    steps:
+                        - step:
+                            name: test
+                            identifier: test
+                            type: Run
+                            timeout: 10m
+                            spec:
+                                command: go test ./...
+                        - stepGroup:
+                            name: publish
+                            identifier: publish
+                            steps:
+                                - step:
+                                    name: push
+                                    identifier: push
+                                    type: BuildAndPushDockerRegistry
+                                    spec:
+                                        repo: app
+    variables:
+        - name: version
+          type: String
+          value: <+input>
+    notificationRules:
+        - name: failures
+          identifier: failures
+          pipelineEvents:
+              - type: PipelineFailed
+          notificationMethod:
+              type: Slack
+              spec:
+                  webhookUrl: https://hooks.slack.com/test
+          enabled: true
+`
+
+func TestFlattenPipelineYaml(t *testing.T) {
+	d := ResourcePipeline().TestResourceData()
+	require.NoError(t, flattenPipelineYaml(d, testStructuredPipelineYaml))
+
+	require.Equal(t, 1, d.Get("stage.#"))
+	require.Equal(t, "CI", d.Get("stage.0.type"))
+	require.Equal(t, "cloneCodebase: true\n", d.Get("stage.0.spec"))
+	require.Equal(t, 1, d.Get("stage.0.step.#"))
+	require.Equal(t, "10m", d.Get("stage.0.step.0.timeout"))
+	require.Equal(t, "command: go test ./...\n", d.Get("stage.0.step.0.spec"))
+	require.Equal(t, "publish", d.Get("stage.0.step_group.0.identifier"))
+	require.Equal(t, "push", d.Get("stage.0.step_group.0.step.0.identifier"))
+	require.Equal(t, "<+input>", d.Get("variable.0.value"))
+	require.Equal(t, "github", d.Get("properties.0.ci_codebase.0.connector_ref"))
+	require.Equal(t, "<+input>\n", d.Get("properties.0.ci_codebase.0.build"))
+	require.Equal(t, []interface{}{"PipelineFailed"}, d.Get("notification_rules.0.pipeline_events"))
+	require.Equal(t, "Slack", d.Get("notification_rules.0.notification_method_type"))
+}
+
+func TestRenderPipelineYaml(t *testing.T) {
+	d := ResourcePipeline().TestResourceData()
+	require.NoError(t, flattenPipelineYaml(d, testStructuredPipelineYaml))
+	d.Set("identifier", "test")
+	d.Set("name", "test")
+	d.Set("org_id", "org")
+	d.Set("project_id", "project")
+	d.Set("tags", []interface{}{"team:cd"})
+
+	rendered, err := renderPipelineYaml(d)
+	require.NoError(t, err)
+	require.True(t, helpers.YamlEqual(testStructuredPipelineYaml, rendered), rendered)
+
+	// The execution is either rendered from the blocks or part of the spec.
+	d.Set("stage", []interface{}{map[string]interface{}{
+		"identifier": "build",
+		"name":       "build",
+		"type":       "CI",
+		"spec":       "execution:\n    steps: []\n",
+		"step":       []interface{}{map[string]interface{}{"identifier": "test", "name": "test", "type": "Run"}},
+	}})
+	_, err = renderPipelineYaml(d)
+	require.EqualError(t, err, "spec of stage build must not contain an `execution` when `step` or `step_group` blocks are set")
+}
+
+func TestFlattenPipelineYamlUnsupported(t *testing.T) {
+	// Rollback steps can't be represented by the blocks so the execution stays in the spec.
+	d := ResourcePipeline().TestResourceData()
+	require.NoError(t, flattenPipelineYaml(d, `
+pipeline:
+    name: test
+    identifier: test
+    projectIdentifier: project
+    orgIdentifier: org
+    allowStageExecutions: false
+    tags: {}
+    stages:
+        - stage:
+            name: deploy
+            identifier: deploy
+            description: ""
+            type: Deployment
+            spec:
+                execution:
+                    steps:
+                        - step:
+                            name: rollout
+                            identifier: rollout
+                            type: K8sRollingDeploy
+                            timeout: 10m
+                            spec: {}
+                    rollbackSteps:
+                        - step:
+                            name: rollback
+                            identifier: rollback
+                            type: K8sRollingRollback
+                            timeout: 10m
+                            spec: {}
+            tags: {}
+`))
+	require.Equal(t, 0, d.Get("stage.0.step.#"))
+	require.Contains(t, d.Get("stage.0.spec"), "rollbackSteps")
+
+	// Parallel stages and pipeline fields without a block are left to the yaml.
+	d = ResourcePipeline().TestResourceData()
+	require.EqualError(t, flattenPipelineYaml(d, `
+pipeline:
+    identifier: test
+    stages:
+        - parallel:
+            - stage:
+                identifier: a
+`), "pipeline test has parallel stages, which can only be managed with `yaml`")
+	require.EqualError(t, flattenPipelineYaml(d, `
+pipeline:
+    identifier: test
+    timeout: 1h
+    stages: []
+`), "pipeline test uses parts of the pipeline YAML that can only be managed with `yaml`")
+	require.Equal(t, 0, d.Get("stage.#"))
+}
@@ -2,6 +2,7 @@ package project
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 
 	"github.com/antihax/optional"
@@ -21,7 +22,7 @@ func ResourceProject() *schema.Resource {
 		UpdateContext: resourceProjectCreateOrUpdate,
 		DeleteContext: resourceProjectDelete,
 		CreateContext: resourceProjectCreateOrUpdate,
-		Importer:      helpers.OrgResourceImporter,
+		Importer:      helpers.OrgResourceImportIdParser.WithLookupByName(lookupProjectByName).Importer(),
 
 		Schema: map[string]*schema.Schema{
 			"color": {
@@ -56,7 +57,7 @@ func resourceProjectRead(ctx context.Context, d *schema.ResourceData, meta inter
 	})
 
 	if err != nil {
-		return helpers.HandleApiError(err, d, httpResp)
+		return helpers.HandleReadApiError(err, d, httpResp)
 	}
 
 	readProject(d, resp.Data.Project)
@@ -125,3 +126,18 @@ func readProject(d *schema.ResourceData, project *nextgen.Project) {
 	d.Set("modules", project.Modules)
 	d.Set("tags", helpers.FlattenTags(project.Tags))
 }
+
+func lookupProjectByName(ctx context.Context, d *schema.ResourceData, meta interface{}, name string) (string, error) {
+	c, ctx := meta.(*internal.Session).GetPlatformClientWithContext(ctx)
+	orgId := d.Get("org_id").(string)
+
+	proj, _, err := c.ProjectApi.GetProjectByName(ctx, c.AccountId, orgId, name)
+	if err != nil {
+		return "", fmt.Errorf("error looking up project %q: %s", name, err)
+	}
+	if proj == nil || proj.Project == nil {
+		return "", fmt.Errorf("no project named %q was found in organization %s", name, orgId)
+	}
+
+	return proj.Project.Identifier, nil
+}
@@ -47,6 +47,10 @@ func resourceSecretFileRead(ctx context.Context, d *schema.ResourceData, meta in
 		return err
 	}
 
+	if secret == nil {
+		return nil
+	}
+
 	if err := readSecretFile(d, secret); err != nil {
 		return diag.FromErr(err)
 	}
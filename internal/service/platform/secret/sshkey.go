@@ -186,6 +186,10 @@ func resourceSecretSSHKeyRead(ctx context.Context, d *schema.ResourceData, meta
 		return err
 	}
 
+	if secret == nil {
+		return nil
+	}
+
 	if err := readSecretSSHKey(d, secret); err != nil {
 		return diag.FromErr(err)
 	}
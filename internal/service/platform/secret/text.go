@@ -50,6 +50,10 @@ func resourceSecretTextRead(ctx context.Context, d *schema.ResourceData, meta in
 		return err
 	}
 
+	if secret == nil {
+		return nil
+	}
+
 	if err := readSecretText(d, secret); err != nil {
 		return diag.FromErr(err)
 	}
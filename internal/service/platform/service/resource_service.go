@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 
 	"github.com/antihax/optional"
@@ -20,14 +21,15 @@ func ResourceService() *schema.Resource {
 		UpdateContext: resourceServiceCreateOrUpdate,
 		DeleteContext: resourceServiceDelete,
 		CreateContext: resourceServiceCreateOrUpdate,
-		Importer:      helpers.ProjectResourceImporter,
+		Importer:      helpers.ProjectResourceImportIdParser.WithLookupByName(lookupServiceByName).Importer(),
 
 		Schema: map[string]*schema.Schema{
 			"yaml": {
-				Description: "Service YAML",
-				Type:        schema.TypeString,
-				Optional:    true,
-				Computed:    true,
+				Description:      "Service YAML",
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				DiffSuppressFunc: helpers.YamlDiffSuppressFunc,
 			},
 		},
 	}
@@ -48,7 +50,7 @@ func resourceServiceRead(ctx context.Context, d *schema.ResourceData, meta inter
 	})
 
 	if err != nil {
-		return helpers.HandleApiError(err, d, httpResp)
+		return helpers.HandleReadApiError(err, d, httpResp)
 	}
 
 	if resp.Data == nil || resp.Data.Service == nil {
@@ -119,3 +121,20 @@ func readService(d *schema.ResourceData, project *nextgen.ServiceResponseDetails
 	d.Set("tags", helpers.FlattenTags(project.Tags))
 	d.Set("yaml", project.Yaml)
 }
+
+func lookupServiceByName(ctx context.Context, d *schema.ResourceData, meta interface{}, name string) (string, error) {
+	c, ctx := meta.(*internal.Session).GetPlatformClientWithContext(ctx)
+
+	svc, _, err := c.ServicesApi.GetServiceByName(ctx, c.AccountId, name, nextgen.GetServiceByNameOpts{
+		OrgIdentifier:     optional.NewString(d.Get("org_id").(string)),
+		ProjectIdentifier: optional.NewString(d.Get("project_id").(string)),
+	})
+	if err != nil {
+		return "", fmt.Errorf("error looking up service %q: %s", name, err)
+	}
+	if svc == nil {
+		return "", fmt.Errorf("no service named %q was found in project %s/%s", name, d.Get("org_id"), d.Get("project_id"))
+	}
+
+	return svc.Identifier, nil
+}
@@ -19,6 +19,13 @@ func ResourceTriggers() *schema.Resource {
 		CreateContext: resourceTriggersCreateOrUpdate,
 		DeleteContext: resourceTriggersDelete,
 		Importer:      helpers.TriggerResourceImporter,
+		CustomizeDiff: helpers.YamlConsistencyCustomizeDiff("trigger", map[string]string{
+			"identifier": "identifier",
+			"name":       "name",
+			"org_id":     "orgIdentifier",
+			"project_id": "projectIdentifier",
+			"target_id":  "pipelineIdentifier",
+		}),
 
 		Schema: map[string]*schema.Schema{
 			"target_id": {
@@ -32,9 +39,10 @@ func ResourceTriggers() *schema.Resource {
 				Optional:    true,
 			},
 			"yaml": {
-				Description: "trigger yaml",
-				Type:        schema.TypeString,
-				Required:    true,
+				Description:      "trigger yaml",
+				Type:             schema.TypeString,
+				Required:         true,
+				DiffSuppressFunc: helpers.YamlDiffSuppressFunc,
 			},
 			"if_match": {
 				Description: "if-Match",
@@ -58,7 +66,7 @@ func resourceTriggersRead(ctx context.Context, d *schema.ResourceData, meta inte
 		d.Get("project_id").(string), d.Get("target_id").(string), id)
 
 	if err != nil {
-		return helpers.HandleApiError(err, d, httpResp)
+		return helpers.HandleReadApiError(err, d, httpResp)
 	}
 
 	readTriggers(d, resp.Data)
@@ -49,7 +49,7 @@ func ResourceUserGroup() *schema.Resource {
 		UpdateContext: resourceUserGroupCreateOrUpdate,
 		DeleteContext: resourceUserGroupDelete,
 		CreateContext: resourceUserGroupCreateOrUpdate,
-		Importer:      helpers.MultiLevelResourceImporter,
+		Importer:      helpers.MultiLevelResourceImportIdParser.WithLookupByName(lookupUserGroupByName).Importer(),
 
 		Schema: map[string]*schema.Schema{
 			// "is_sso_linked": {
@@ -154,7 +154,7 @@ func resourceUserGroupRead(ctx context.Context, d *schema.ResourceData, meta int
 	})
 
 	if err != nil {
-		return helpers.HandleApiError(err, d, httpResp)
+		return helpers.HandleReadApiError(err, d, httpResp)
 	}
 
 	// Soft delete lookup error handling
@@ -360,3 +360,20 @@ func flattenNotificationConfig(notificationConfigs []nextgen.NotificationSetting
 	}
 	return result
 }
+
+func lookupUserGroupByName(ctx context.Context, d *schema.ResourceData, meta interface{}, name string) (string, error) {
+	c, ctx := meta.(*internal.Session).GetPlatformClientWithContext(ctx)
+
+	ug, _, err := c.UserGroupApi.GetUserGroupByName(ctx, c.AccountId, name, &nextgen.UserGroupApiGetUserGroupByNameOpts{
+		OrgIdentifier:     helpers.BuildField(d, "org_id"),
+		ProjectIdentifier: helpers.BuildField(d, "project_id"),
+	})
+	if err != nil {
+		return "", fmt.Errorf("error looking up user group %q: %s", name, err)
+	}
+	if ug == nil {
+		return "", fmt.Errorf("no user group named %q was found", name)
+	}
+
+	return ug.Identifier, nil
+}
@@ -2,16 +2,65 @@ package internal
 
 import (
 	"context"
+	"net/http"
+	"sync"
 
 	"github.com/harness/harness-go-sdk/harness/cd"
 	"github.com/harness/harness-go-sdk/harness/nextgen"
+	"github.com/hashicorp/go-retryablehttp"
 )
 
+// Session holds the provider configuration and the API clients shared by the resources and data
+// sources. The opt-in read cache is part of the transport of the Next Gen clients, see ReadCache.
 type Session struct {
-	AccountId string
-	Endpoint  string
-	CDClient  *cd.ApiClient
-	PLClient  *nextgen.APIClient
+	AccountId        string
+	Endpoint         string
+	DefaultOrgId     string
+	DefaultProjectId string
+	DefaultTags      map[string]string
+	AdoptExisting    bool
+
+	// NewCDClient and NewPLClient build the First Gen and Next Gen clients the first time they are
+	// used. They are nil when the api key for that generation isn't configured.
+	NewCDClient func() (*cd.ApiClient, error)
+	NewPLClient func() *nextgen.APIClient
+
+	// PLHTTPClient sends the Next Gen requests that aren't made with the SDK, see PlatformRequest.
+	PLHTTPClient *retryablehttp.Client
+
+	cdOnce   sync.Once
+	cdClient *cd.ApiClient
+	cdErr    error
+
+	plOnce   sync.Once
+	plClient *nextgen.APIClient
+}
+
+// HasCDClient reports whether the First Gen client can be used, i.e. `api_key` is configured.
+func (s *Session) HasCDClient() bool {
+	return s.NewCDClient != nil
+}
+
+// HasPLClient reports whether the Next Gen client can be used, i.e. `platform_api_key` is configured.
+func (s *Session) HasPLClient() bool {
+	return s.NewPLClient != nil
+}
+
+// GetCDClientWithError returns the First Gen client, building it on first use.
+func (s *Session) GetCDClientWithError() (*cd.ApiClient, error) {
+	s.cdOnce.Do(func() {
+		if s.NewCDClient != nil {
+			s.cdClient, s.cdErr = s.NewCDClient()
+		}
+	})
+	return s.cdClient, s.cdErr
+}
+
+// GetCDClient returns the First Gen client. Resources only call it once the provider has checked
+// that the client is configured.
+func (s *Session) GetCDClient() *cd.ApiClient {
+	c, _ := s.GetCDClientWithError()
+	return c
 }
 
 func (s *Session) GetPlatformClient() (*nextgen.APIClient, context.Context) {
@@ -23,5 +72,102 @@ func (s *Session) GetPlatformClientWithContext(ctx context.Context) (*nextgen.AP
 		ctx = context.Background()
 	}
 
-	return s.PLClient.WithAuthContext(ctx)
+	c := s.getPLClient()
+	if c == nil {
+		return nil, ctx
+	}
+
+	return c.WithAuthContext(ctx)
+}
+
+func (s *Session) getPLClient() *nextgen.APIClient {
+	s.plOnce.Do(func() {
+		if s.NewPLClient != nil {
+			s.plClient = s.NewPLClient()
+		}
+	})
+	return s.plClient
+}
+
+// WithContext returns a copy of the session whose First Gen client stops making requests once ctx
+// is done. The Next Gen client takes the context of each call, but the First Gen SDK doesn't, so
+// this is how resource timeouts reach it.
+func (s *Session) WithContext(ctx context.Context) *Session {
+	session := &Session{
+		AccountId:        s.AccountId,
+		Endpoint:         s.Endpoint,
+		DefaultOrgId:     s.DefaultOrgId,
+		DefaultProjectId: s.DefaultProjectId,
+		DefaultTags:      s.DefaultTags,
+		AdoptExisting:    s.AdoptExisting,
+		PLHTTPClient:     s.PLHTTPClient,
+	}
+
+	if s.NewPLClient != nil {
+		session.NewPLClient = s.getPLClient
+	}
+
+	if s.NewCDClient != nil {
+		session.NewCDClient = func() (*cd.ApiClient, error) {
+			c, err := s.GetCDClientWithError()
+			if err != nil {
+				return nil, err
+			}
+
+			cfg := *c.Configuration
+			cfg.DefaultHeaders = make(map[string]string, len(c.Configuration.DefaultHeaders))
+			for k, v := range c.Configuration.DefaultHeaders {
+				cfg.DefaultHeaders[k] = v
+			}
+			cfg.HTTPClient = withContext(cfg.HTTPClient, ctx)
+			return cd.NewClient(&cfg)
+		}
+	}
+
+	return session
+}
+
+// withContext returns a copy of httpClient that sends its requests with ctx and stops retrying
+// once ctx is done.
+func withContext(httpClient *retryablehttp.Client, ctx context.Context) *retryablehttp.Client {
+	checkRetry := httpClient.CheckRetry
+	if checkRetry == nil {
+		checkRetry = retryablehttp.DefaultRetryPolicy
+	}
+
+	return &retryablehttp.Client{
+		HTTPClient: &http.Client{
+			Transport:     &contextTransport{ctx: ctx, transport: httpClient.HTTPClient.Transport},
+			CheckRedirect: httpClient.HTTPClient.CheckRedirect,
+			Jar:           httpClient.HTTPClient.Jar,
+			Timeout:       httpClient.HTTPClient.Timeout,
+		},
+		Logger:          httpClient.Logger,
+		RetryWaitMin:    httpClient.RetryWaitMin,
+		RetryWaitMax:    httpClient.RetryWaitMax,
+		RetryMax:        httpClient.RetryMax,
+		RequestLogHook:  httpClient.RequestLogHook,
+		ResponseLogHook: httpClient.ResponseLogHook,
+		CheckRetry: func(_ context.Context, resp *http.Response, err error) (bool, error) {
+			if ctx.Err() != nil {
+				return false, ctx.Err()
+			}
+			return checkRetry(ctx, resp, err)
+		},
+		Backoff:      httpClient.Backoff,
+		ErrorHandler: httpClient.ErrorHandler,
+	}
+}
+
+type contextTransport struct {
+	ctx       context.Context
+	transport http.RoundTripper
+}
+
+func (t *contextTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	transport := t.transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	return transport.RoundTrip(req.WithContext(t.ctx))
 }
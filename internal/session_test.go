@@ -0,0 +1,52 @@
+package internal
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/harness/harness-go-sdk/harness/cd"
+	"github.com/hashicorp/go-retryablehttp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSessionWithContext(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.Copy(io.Discard, r.Body)
+		select {
+		case <-r.Context().Done():
+		case <-time.After(5 * time.Second):
+		}
+	}))
+	defer server.Close()
+
+	builds := 0
+	session := &Session{
+		AccountId: "test",
+		NewCDClient: func() (*cd.ApiClient, error) {
+			builds++
+			cfg := cd.DefaultConfig()
+			cfg.AccountId = "test"
+			cfg.APIKey = "test"
+			cfg.Endpoint = server.URL
+			cfg.HTTPClient = retryablehttp.NewClient()
+			return cd.NewClient(cfg)
+		},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := session.WithContext(ctx).GetCDClient().ApplicationClient.GetApplicationById("test")
+	require.Error(t, err)
+	require.Less(t, time.Since(start), 2*time.Second)
+
+	// The copy shares the underlying client and leaves the session's own client untouched.
+	require.Equal(t, 1, builds)
+	require.IsType(t, &http.Transport{}, session.GetCDClient().Configuration.HTTPClient.HTTPClient.Transport)
+	require.False(t, session.WithContext(ctx).HasPLClient())
+}
@@ -47,11 +47,16 @@ func InterfaceSliceToStringSlice(slice []interface{}) []string {
 	return ss
 }
 
+// ExpandTags parses tags in the form `name:value`. Only the first colon separates the name from the
+// value and tags without a colon have an empty value.
 func ExpandTags(tags []interface{}) map[string]string {
 	result := map[string]string{}
 
 	for _, tag := range tags {
-		parts := strings.Split(tag.(string), ":")
+		parts := strings.SplitN(tag.(string), ":", 2)
+		if len(parts) == 1 {
+			parts = append(parts, "")
+		}
 		result[parts[0]] = parts[1]
 	}
 
@@ -61,7 +66,11 @@ func ExpandTags(tags []interface{}) map[string]string {
 func FlattenTags(tags map[string]string) []string {
 	var result []string
 	for k, v := range tags {
-		result = append(result, k+":"+v)
+		if v == "" {
+			result = append(result, k)
+		} else {
+			result = append(result, k+":"+v)
+		}
 	}
 	return result
 }
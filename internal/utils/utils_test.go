@@ -26,3 +26,14 @@ func TestGetConflictsWithSlice(t *testing.T) {
 
 	require.Len(t, source, expectedItemCount)
 }
+
+func TestExpandTags(t *testing.T) {
+	tags := utils.ExpandTags([]interface{}{"url:https://example.com:8443", "team:a:b", "bare"})
+	require.Equal(t, map[string]string{"url": "https://example.com:8443", "team": "a:b", "bare": ""}, tags)
+
+	var flattened []interface{}
+	for _, tag := range utils.FlattenTags(tags) {
+		flattened = append(flattened, tag)
+	}
+	require.Equal(t, tags, utils.ExpandTags(flattened))
+}